@@ -0,0 +1,211 @@
+// Package aggregator combines the health of remote services, such as every service of a namespace, into a single
+// response and an HTML summary.
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+	"github.com/jamillosantos/services-healthcheck/probe"
+)
+
+// cache keeps the last combined response of a kind. Concurrent requests share the refresh in progress.
+type cache struct {
+	mu       sync.Mutex
+	response *svchealthcheck.CheckResponse
+	expires  time.Time
+	refresh  *refresh
+}
+
+// refresh is a refresh of a cache in progress. done is closed when response and err are set.
+type refresh struct {
+	done     chan struct{}
+	response *svchealthcheck.CheckResponse
+	err      error
+}
+
+// Aggregator fetches the health and ready endpoints of the targets provided by a Discoverer and combines them.
+type Aggregator struct {
+	discoverer Discoverer
+	opts       options
+	now        func() time.Time
+	health     cache
+	ready      cache
+}
+
+// New returns an Aggregator of the targets provided by the discoverer.
+func New(discoverer Discoverer, opts ...Option) *Aggregator {
+	o := defaultOpts()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Aggregator{
+		discoverer: discoverer,
+		opts:       o,
+		now:        time.Now,
+	}
+}
+
+// Health returns the combined health responses of the targets.
+func (a *Aggregator) Health(ctx context.Context) (*svchealthcheck.CheckResponse, error) {
+	return a.cached(ctx, &a.health, svchealthcheck.KindHealth)
+}
+
+// Ready returns the combined ready responses of the targets.
+func (a *Aggregator) Ready(ctx context.Context) (*svchealthcheck.CheckResponse, error) {
+	return a.cached(ctx, &a.ready, svchealthcheck.KindReady)
+}
+
+// cached returns the cached response of the kind, refreshing it when expired. The refresh is shared by every caller,
+// so it runs in background, detached from the caller context: the requests to the targets are only bound by the timeout
+// set by WithTimeout. A caller that is gone returns its context error, and the refresh still fills the cache.
+func (a *Aggregator) cached(ctx context.Context, c *cache, kind svchealthcheck.CheckKind) (*svchealthcheck.CheckResponse, error) {
+	c.mu.Lock()
+	if c.response != nil && a.now().Before(c.expires) {
+		r := c.response
+		c.mu.Unlock()
+		return r, nil
+	}
+	ref := c.refresh
+	if ref == nil {
+		ref = &refresh{done: make(chan struct{})}
+		c.refresh = ref
+		go a.refresh(context.WithoutCancel(ctx), c, ref, kind)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-ref.done:
+		return ref.response, ref.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// refresh combines the responses of the targets into ref, caching the response when it succeeds.
+func (a *Aggregator) refresh(ctx context.Context, c *cache, ref *refresh, kind svchealthcheck.CheckKind) {
+	now := a.now()
+	ref.response, ref.err = a.combine(ctx, kind)
+
+	c.mu.Lock()
+	if ref.err == nil {
+		c.response = ref.response
+		c.expires = now.Add(a.opts.cacheTTL)
+	}
+	c.refresh = nil
+	c.mu.Unlock()
+	close(ref.done)
+}
+
+// combine requests the endpoint of the given kind of all the targets, in parallel. Each target becomes an entry of
+// the response, with the checks of the target as its components. The response fails if any target fails.
+func (a *Aggregator) combine(ctx context.Context, kind svchealthcheck.CheckKind) (*svchealthcheck.CheckResponse, error) {
+	targets, err := a.discoverer.Targets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	r := &svchealthcheck.CheckResponse{
+		Outcome: svchealthcheck.OutcomePass,
+		Checks:  make(map[string]svchealthcheck.CheckResponseEntry, len(targets)),
+	}
+	wg.Add(len(targets))
+	for _, target := range targets {
+		go func(target Target) {
+			defer wg.Done()
+			entry := a.fetch(ctx, target, kind)
+
+			name := target.Name
+			if name == "" {
+				name = target.Address
+			}
+			mu.Lock()
+			r.Checks[name] = entry
+			r.Outcome = svchealthcheck.WorstOutcome(r.Outcome, entry.Outcome)
+			mu.Unlock()
+		}(target)
+	}
+	wg.Wait()
+
+	r.StatusCode = http.StatusOK
+	if r.Outcome.Failed() {
+		r.StatusCode = http.StatusServiceUnavailable
+	}
+	r.Status = http.StatusText(r.StatusCode)
+	return r, nil
+}
+
+// fetch requests the endpoint of the target and converts its response into an entry. Unreachable targets have the
+// unknown outcome.
+func (a *Aggregator) fetch(ctx context.Context, target Target, kind svchealthcheck.CheckKind) svchealthcheck.CheckResponseEntry {
+	if a.opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.opts.timeout)
+		defer cancel()
+	}
+
+	st := time.Now()
+	r, err := probe.Probe(ctx, target.Address, kind, a.opts.probe...)
+	entry := svchealthcheck.CheckResponseEntry{
+		Duration: time.Since(st).String(),
+		Details: map[string]interface{}{
+			"address": target.Address,
+		},
+	}
+	if err != nil {
+		entry.Outcome = svchealthcheck.OutcomeUnknown
+		entry.Error = err.Error()
+		return entry
+	}
+
+	entry.Outcome = r.Outcome
+	if entry.Outcome == "" {
+		entry.Outcome = svchealthcheck.OutcomePass
+		if !probe.Healthy(r) {
+			entry.Outcome = svchealthcheck.OutcomeFail
+		}
+	}
+	if !probe.Healthy(r) {
+		entry.Error = r.Status
+	}
+	entry.Details["statusCode"] = r.StatusCode
+	entry.Components = r.Checks
+	return entry
+}
+
+// Handler returns the http.Handler serving the combined health and ready responses, on svchealthcheck.HealthPath and
+// svchealthcheck.ReadyPath, and the HTML summary of both on the root path.
+func (a *Aggregator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(svchealthcheck.HealthPath, a.jsonEndpoint(a.Health))
+	mux.HandleFunc(svchealthcheck.ReadyPath, a.jsonEndpoint(a.Ready))
+	mux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/" {
+			http.NotFound(writer, request)
+			return
+		}
+		a.serveSummary(writer, request)
+	})
+	return mux
+}
+
+func (a *Aggregator) jsonEndpoint(getResponse func(ctx context.Context) (*svchealthcheck.CheckResponse, error)) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		r, err := getResponse(request.Context())
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(r.StatusCode)
+		_ = json.NewEncoder(writer).Encode(r)
+	}
+}
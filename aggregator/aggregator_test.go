@@ -0,0 +1,255 @@
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+// newTarget starts a httptest.Server replying with the given responses on the health and ready paths. It returns the
+// number of requests received.
+func newTarget(t *testing.T, health, ready *svchealthcheck.CheckResponse) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	write := func(r *svchealthcheck.CheckResponse) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			w.WriteHeader(r.StatusCode)
+			_ = json.NewEncoder(w).Encode(r)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(svchealthcheck.HealthPath, write(health))
+	mux.HandleFunc(svchealthcheck.ReadyPath, write(ready))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+var (
+	passing = &svchealthcheck.CheckResponse{
+		StatusCode: http.StatusOK,
+		Status:     "OK",
+		Outcome:    svchealthcheck.OutcomePass,
+		Checks: map[string]svchealthcheck.CheckResponseEntry{
+			"database": {Outcome: svchealthcheck.OutcomePass},
+		},
+	}
+	failing = &svchealthcheck.CheckResponse{
+		StatusCode: http.StatusServiceUnavailable,
+		Status:     "Service Unavailable",
+		Outcome:    svchealthcheck.OutcomeFail,
+		Checks: map[string]svchealthcheck.CheckResponseEntry{
+			"queue": {Outcome: svchealthcheck.OutcomeFail, Error: "connection refused"},
+		},
+	}
+)
+
+func TestAggregator_Health(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should combine the responses of the targets", func(t *testing.T) {
+		api, _ := newTarget(t, passing, passing)
+		worker, _ := newTarget(t, failing, failing)
+		a := New(Static(Target{Name: "api", Address: api.URL}, Target{Address: worker.URL}))
+
+		r, err := a.Health(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, r.StatusCode)
+		assert.Equal(t, "Service Unavailable", r.Status)
+		assert.Equal(t, svchealthcheck.OutcomeFail, r.Outcome)
+		require.Len(t, r.Checks, 2)
+
+		apiEntry := r.Checks["api"]
+		assert.Equal(t, svchealthcheck.OutcomePass, apiEntry.Outcome)
+		assert.Empty(t, apiEntry.Error)
+		assert.Equal(t, api.URL, apiEntry.Details["address"])
+		assert.Equal(t, http.StatusOK, apiEntry.Details["statusCode"])
+		assert.Contains(t, apiEntry.Components, "database")
+
+		workerEntry := r.Checks[worker.URL]
+		assert.Equal(t, svchealthcheck.OutcomeFail, workerEntry.Outcome)
+		assert.Equal(t, "Service Unavailable", workerEntry.Error)
+		assert.Equal(t, "connection refused", workerEntry.Components["queue"].Error)
+	})
+
+	t.Run("should pass when all targets pass", func(t *testing.T) {
+		api, _ := newTarget(t, passing, passing)
+		r, err := New(Static(Target{Name: "api", Address: api.URL})).Health(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, r.StatusCode)
+		assert.Equal(t, svchealthcheck.OutcomePass, r.Outcome)
+	})
+
+	t.Run("should derive the outcome from the status code", func(t *testing.T) {
+		legacy, _ := newTarget(t, &svchealthcheck.CheckResponse{StatusCode: http.StatusInternalServerError, Status: "Internal Server Error"}, passing)
+		r, err := New(Static(Target{Name: "legacy", Address: legacy.URL})).Health(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, svchealthcheck.OutcomeFail, r.Checks["legacy"].Outcome)
+	})
+
+	t.Run("should report unreachable targets as unknown", func(t *testing.T) {
+		stopped, _ := newTarget(t, passing, passing)
+		stopped.Close()
+
+		r, err := New(Static(Target{Name: "stopped", Address: stopped.URL})).Health(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, r.StatusCode)
+		assert.Equal(t, svchealthcheck.OutcomeUnknown, r.Checks["stopped"].Outcome)
+		assert.NotEmpty(t, r.Checks["stopped"].Error)
+	})
+
+	t.Run("should time out slow targets", func(t *testing.T) {
+		release := make(chan struct{})
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		t.Cleanup(slow.Close)
+		t.Cleanup(func() {
+			close(release)
+		})
+
+		st := time.Now()
+		r, err := New(Static(Target{Name: "slow", Address: slow.URL}), WithTimeout(50*time.Millisecond)).Health(ctx)
+		require.NoError(t, err)
+		assert.Less(t, time.Since(st), time.Second)
+		assert.Equal(t, svchealthcheck.OutcomeUnknown, r.Checks["slow"].Outcome)
+		assert.Contains(t, r.Checks["slow"].Error, "deadline exceeded")
+	})
+
+	t.Run("should fail when the targets cannot be discovered", func(t *testing.T) {
+		wantErr := errors.New("discovery failed")
+		_, err := New(DiscovererFunc(func(ctx context.Context) ([]Target, error) {
+			return nil, wantErr
+		})).Health(ctx)
+		assert.ErrorIs(t, err, wantErr)
+	})
+}
+
+func TestAggregator_cache(t *testing.T) {
+	ctx := context.Background()
+	api, requests := newTarget(t, passing, failing)
+	a := New(Static(Target{Name: "api", Address: api.URL}), WithCacheTTL(time.Minute))
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	a.now = func() time.Time {
+		return now
+	}
+
+	first, err := a.Health(ctx)
+	require.NoError(t, err)
+	second, err := a.Health(ctx)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+
+	ready, err := a.Ready(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, ready.StatusCode, "the kinds should be cached apart")
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+
+	now = now.Add(time.Minute)
+	_, err = a.Health(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestAggregator_cache_cancelledCaller(t *testing.T) {
+	started := make(chan struct{}, 1)
+	unblock := make(chan struct{})
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			started <- struct{}{}
+			<-unblock
+		}
+		w.WriteHeader(passing.StatusCode)
+		_ = json.NewEncoder(w).Encode(passing)
+	}))
+	t.Cleanup(srv.Close)
+	a := New(Static(Target{Name: "api", Address: srv.URL}), WithCacheTTL(time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := a.Health(ctx)
+		errs <- err
+	}()
+	<-started
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled, "the caller should not wait for the refresh")
+	close(unblock)
+
+	r, err := a.Health(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, r.StatusCode, "the refresh should not be cancelled with the caller")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "the refresh of the cancelled caller should be cached")
+}
+
+func TestAggregator_headers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response := passing
+		if r.Header.Get("Authorization") != "Bearer token" {
+			response = &svchealthcheck.CheckResponse{StatusCode: http.StatusOK, Status: "OK", Outcome: svchealthcheck.OutcomePass}
+		}
+		w.WriteHeader(response.StatusCode)
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(srv.Close)
+
+	a := New(Static(Target{Name: "api", Address: srv.URL}), WithHeader("Authorization", "Bearer token"))
+	r, err := a.Health(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, r.Checks["api"].Components, "database")
+}
+
+func TestAggregator_Handler(t *testing.T) {
+	api, _ := newTarget(t, passing, failing)
+	handler := New(Static(Target{Name: "api", Address: api.URL})).Handler()
+
+	t.Run("should serve the combined health response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", svchealthcheck.HealthPath, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var r svchealthcheck.CheckResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&r))
+		assert.Equal(t, svchealthcheck.OutcomePass, r.Checks["api"].Outcome)
+	})
+
+	t.Run("should serve the combined ready response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", svchealthcheck.ReadyPath, nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+
+	t.Run("should serve the summary on the root path only", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "<td>api</td>")
+
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", "/unknown", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should fail when the targets cannot be discovered", func(t *testing.T) {
+		handler := New(File("/nonexistent/targets.json")).Handler()
+		for _, path := range []string{svchealthcheck.HealthPath, "/"} {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+			assert.Equal(t, http.StatusInternalServerError, w.Code, path)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package aggregator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Target is a service whose health is aggregated.
+type Target struct {
+	// Name identifies the target in the combined response. It defaults to the address.
+	Name string `json:"name"`
+	// Address is the base URL of the service, or the path of its Unix socket, as accepted by probe.Probe.
+	Address string `json:"address"`
+}
+
+// Discoverer provides the targets to aggregate. It is called on every refresh, so the targets can change over time.
+type Discoverer interface {
+	Targets(ctx context.Context) ([]Target, error)
+}
+
+// DiscovererFunc is a Discoverer defined as a function.
+type DiscovererFunc func(ctx context.Context) ([]Target, error)
+
+// Targets implements the Discoverer interface.
+func (f DiscovererFunc) Targets(ctx context.Context) ([]Target, error) {
+	return f(ctx)
+}
+
+// Static returns a Discoverer that always provides the given targets.
+func Static(targets ...Target) Discoverer {
+	return DiscovererFunc(func(_ context.Context) ([]Target, error) {
+		return targets, nil
+	})
+}
+
+// File returns a Discoverer that reads the targets from a JSON file with a list of targets, such as:
+//
+//	[{"name": "api", "address": "http://api:8082"}, {"name": "worker", "address": "http://worker:8082"}]
+//
+// The file is read on every refresh, so it can be updated, for example by a ConfigMap, without restarting.
+func File(path string) Discoverer {
+	return DiscovererFunc(func(_ context.Context) ([]Target, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var targets []Target
+		if err := json.Unmarshal(data, &targets); err != nil {
+			return nil, fmt.Errorf("invalid targets file %s: %w", path, err)
+		}
+		return targets, nil
+	})
+}
@@ -0,0 +1,53 @@
+package aggregator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatic(t *testing.T) {
+	targets, err := Static(Target{Name: "api", Address: "http://api:8082"}).Targets(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Target{{Name: "api", Address: "http://api:8082"}}, targets)
+}
+
+func TestFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "targets.json")
+
+	t.Run("should read the targets from the file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`[{"name": "api", "address": "http://api:8082"}]`), 0o600))
+		targets, err := File(path).Targets(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []Target{{Name: "api", Address: "http://api:8082"}}, targets)
+	})
+
+	t.Run("should read the file again on every call", func(t *testing.T) {
+		discoverer := File(path)
+		require.NoError(t, os.WriteFile(path, []byte(`[]`), 0o600))
+		targets, err := discoverer.Targets(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, targets)
+
+		require.NoError(t, os.WriteFile(path, []byte(`[{"address": "unix:///var/run/worker.sock"}]`), 0o600))
+		targets, err = discoverer.Targets(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []Target{{Address: "unix:///var/run/worker.sock"}}, targets)
+	})
+
+	t.Run("should fail when the file is invalid", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`{`), 0o600))
+		_, err := File(path).Targets(ctx)
+		assert.ErrorContains(t, err, "invalid targets file")
+	})
+
+	t.Run("should fail when the file does not exist", func(t *testing.T) {
+		_, err := File(filepath.Join(t.TempDir(), "missing.json")).Targets(ctx)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
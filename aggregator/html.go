@@ -0,0 +1,133 @@
+package aggregator
+
+import (
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+var summaryTemplate = template.Must(template.New("summary").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Health summary</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.4em 0.8em; text-align: left; vertical-align: top; }
+.pass { color: #1a7f37; } .skip { color: #57606a; } .warn { color: #9a6700; } .unknown, .fail { color: #cf222e; }
+ul { margin: 0; padding-left: 1.2em; }
+</style>
+</head>
+<body>
+<h1>Health summary</h1>
+<p>Health: <span class="{{.Health.Outcome}}">{{.Health.Outcome}}</span>, ready: <span class="{{.Ready.Outcome}}">{{.Ready.Outcome}}</span>. Generated at {{.Time.Format "2006-01-02 15:04:05 MST"}}.</p>
+<table>
+<tr><th>Target</th><th>Health</th><th>Ready</th><th>Failing checks</th></tr>
+{{- range .Targets}}
+<tr>
+<td>{{.Name}}</td>
+<td class="{{.Health.Outcome}}">{{.Health.Outcome}}{{with .Health.Error}}: {{.}}{{end}}</td>
+<td class="{{.Ready.Outcome}}">{{.Ready.Outcome}}{{with .Ready.Error}}: {{.}}{{end}}</td>
+<td>{{with .Failing}}<ul>{{range .}}<li>{{.}}</li>{{end}}</ul>{{end}}</td>
+</tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+type summaryTarget struct {
+	Name    string
+	Health  svchealthcheck.CheckResponseEntry
+	Ready   svchealthcheck.CheckResponseEntry
+	Failing []string
+}
+
+type summary struct {
+	Time    time.Time
+	Health  *svchealthcheck.CheckResponse
+	Ready   *svchealthcheck.CheckResponse
+	Targets []summaryTarget
+}
+
+// serveSummary renders the HTML summary of the health and ready responses of the targets.
+func (a *Aggregator) serveSummary(writer http.ResponseWriter, request *http.Request) {
+	health, err := a.Health(request.Context())
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	ready, err := a.Ready(request.Context())
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	writer.WriteHeader(health.StatusCode)
+	_ = summaryTemplate.Execute(writer, newSummary(a.now(), health, ready))
+}
+
+func newSummary(now time.Time, health, ready *svchealthcheck.CheckResponse) summary {
+	names := make(map[string]struct{}, len(health.Checks))
+	for name := range health.Checks {
+		names[name] = struct{}{}
+	}
+	for name := range ready.Checks {
+		names[name] = struct{}{}
+	}
+
+	s := summary{
+		Time:    now,
+		Health:  health,
+		Ready:   ready,
+		Targets: make([]summaryTarget, 0, len(names)),
+	}
+	for name := range names {
+		target := summaryTarget{
+			Name:   name,
+			Health: health.Checks[name],
+			Ready:  ready.Checks[name],
+		}
+		target.Failing = failingChecks("", target.Health.Components, target.Failing)
+		target.Failing = failingChecks("", target.Ready.Components, target.Failing)
+		sort.Strings(target.Failing)
+		target.Failing = dedup(target.Failing)
+		s.Targets = append(s.Targets, target)
+	}
+	sort.Slice(s.Targets, func(i, j int) bool {
+		return s.Targets[i].Name < s.Targets[j].Name
+	})
+	return s
+}
+
+// failingChecks appends the checks, and components, that failed to r, described by their path and error.
+func failingChecks(prefix string, entries map[string]svchealthcheck.CheckResponseEntry, r []string) []string {
+	for name, entry := range entries {
+		path := prefix + name
+		if entry.Outcome.Failed() {
+			description := path
+			if entry.Error != "" {
+				description += ": " + entry.Error
+			}
+			r = append(r, description)
+		}
+		r = failingChecks(path+".", entry.Components, r)
+	}
+	return r
+}
+
+// dedup removes the consecutive duplicates of the sorted slice.
+func dedup(s []string) []string {
+	r := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			r = append(r, v)
+		}
+	}
+	return r
+}
@@ -0,0 +1,70 @@
+package aggregator
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func Test_newSummary(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	health := &svchealthcheck.CheckResponse{
+		StatusCode: 503,
+		Outcome:    svchealthcheck.OutcomeFail,
+		Checks: map[string]svchealthcheck.CheckResponseEntry{
+			"worker": {
+				Outcome: svchealthcheck.OutcomeFail,
+				Error:   "Service Unavailable",
+				Components: map[string]svchealthcheck.CheckResponseEntry{
+					"queue": {Outcome: svchealthcheck.OutcomeFail, Error: "connection refused"},
+					"brokers": {
+						Outcome: svchealthcheck.OutcomeFail,
+						Components: map[string]svchealthcheck.CheckResponseEntry{
+							"0": {Outcome: svchealthcheck.OutcomeFail},
+							"1": {Outcome: svchealthcheck.OutcomePass},
+						},
+					},
+				},
+			},
+			"api": {Outcome: svchealthcheck.OutcomePass},
+		},
+	}
+	ready := &svchealthcheck.CheckResponse{
+		StatusCode: 503,
+		Outcome:    svchealthcheck.OutcomeFail,
+		Checks: map[string]svchealthcheck.CheckResponseEntry{
+			"worker": {
+				Outcome: svchealthcheck.OutcomeFail,
+				Components: map[string]svchealthcheck.CheckResponseEntry{
+					"queue": {Outcome: svchealthcheck.OutcomeFail, Error: "connection refused"},
+				},
+			},
+			"api": {Outcome: svchealthcheck.OutcomeWarn},
+		},
+	}
+
+	s := newSummary(now, health, ready)
+
+	require.Len(t, s.Targets, 2)
+	assert.Equal(t, "api", s.Targets[0].Name)
+	assert.Equal(t, svchealthcheck.OutcomeWarn, s.Targets[0].Ready.Outcome)
+	assert.Empty(t, s.Targets[0].Failing)
+	assert.Equal(t, "worker", s.Targets[1].Name)
+	assert.Equal(t, []string{"brokers", "brokers.0", "queue: connection refused"}, s.Targets[1].Failing)
+
+	var buf bytes.Buffer
+	require.NoError(t, summaryTemplate.Execute(&buf, s))
+	assert.Contains(t, buf.String(), `<td class="fail">fail: Service Unavailable</td>`)
+	assert.Contains(t, buf.String(), "<li>queue: connection refused</li>")
+	assert.Contains(t, buf.String(), "2022-01-01 00:00:00 UTC")
+}
+
+func Test_dedup(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, dedup([]string{"a", "a", "b"}))
+	assert.Empty(t, dedup(nil))
+}
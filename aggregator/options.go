@@ -0,0 +1,53 @@
+package aggregator
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jamillosantos/services-healthcheck/probe"
+)
+
+type Option func(*options)
+
+type options struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+	probe    []probe.Option
+}
+
+func defaultOpts() options {
+	return options{
+		timeout:  5 * time.Second,
+		cacheTTL: 10 * time.Second,
+	}
+}
+
+// WithTimeout sets the timeout of the request to each target. It defaults to 5 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithCacheTTL sets how long the combined responses are reused before the targets are requested again. It defaults to
+// 10 seconds and a zero TTL disables the cache.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.cacheTTL = ttl
+	}
+}
+
+// WithHTTPClient sets the client of the requests to the targets. It defaults to http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.probe = append(o.probe, probe.WithHTTPClient(client))
+	}
+}
+
+// WithHeader adds a header to the requests to the targets, such as the credentials required by targets that only
+// return their checks to authenticated requests. It can be used multiple times.
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		o.probe = append(o.probe, probe.WithHeader(key, value))
+	}
+}
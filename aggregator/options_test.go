@@ -0,0 +1,34 @@
+package aggregator
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeout(t *testing.T) {
+	opts := defaultOpts()
+	WithTimeout(time.Second)(&opts)
+	assert.Equal(t, time.Second, opts.timeout)
+}
+
+func TestWithCacheTTL(t *testing.T) {
+	opts := defaultOpts()
+	WithCacheTTL(time.Minute)(&opts)
+	assert.Equal(t, time.Minute, opts.cacheTTL)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	opts := defaultOpts()
+	WithHTTPClient(&http.Client{})(&opts)
+	assert.Len(t, opts.probe, 1)
+}
+
+func TestWithHeader(t *testing.T) {
+	opts := defaultOpts()
+	WithHeader("Authorization", "Bearer token")(&opts)
+	WithHeader("X-Tenant", "a")(&opts)
+	assert.Len(t, opts.probe, 2)
+}
@@ -0,0 +1,37 @@
+package svchealthcheck
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+)
+
+// CheckBearerToken returns true if the given Authorization header value carries the given bearer token. The
+// comparison is done in constant time.
+func CheckBearerToken(authorization, token string) bool {
+	const prefix = "Bearer "
+	if token == "" || len(authorization) < len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(authorization[len(prefix):]), []byte(token)) == 1
+}
+
+// CheckBasicAuth returns true if the given Authorization header value carries the given basic auth credentials. The
+// comparison is done in constant time. An empty password never matches.
+func CheckBasicAuth(authorization, username, password string) bool {
+	const prefix = "Basic "
+	if password == "" || len(authorization) < len(prefix) || !strings.EqualFold(authorization[:len(prefix)], prefix) {
+		return false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(authorization[len(prefix):])
+	if err != nil {
+		return false
+	}
+	gotUsername, gotPassword, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return false
+	}
+	usernameOk := subtle.ConstantTimeCompare([]byte(gotUsername), []byte(username)) == 1
+	passwordOk := subtle.ConstantTimeCompare([]byte(gotPassword), []byte(password)) == 1
+	return usernameOk && passwordOk
+}
@@ -0,0 +1,32 @@
+package svchealthcheck
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckBearerToken(t *testing.T) {
+	assert.True(t, CheckBearerToken("Bearer s3cr3t", "s3cr3t"))
+	assert.True(t, CheckBearerToken("bearer s3cr3t", "s3cr3t"))
+	assert.False(t, CheckBearerToken("Bearer wrong", "s3cr3t"))
+	assert.False(t, CheckBearerToken("s3cr3t", "s3cr3t"))
+	assert.False(t, CheckBearerToken("", "s3cr3t"))
+	assert.False(t, CheckBearerToken("Bearer ", ""))
+}
+
+func TestCheckBasicAuth(t *testing.T) {
+	basic := func(s string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(s))
+	}
+
+	assert.True(t, CheckBasicAuth(basic("admin:s3cr3t"), "admin", "s3cr3t"))
+	assert.False(t, CheckBasicAuth(basic("admin:wrong"), "admin", "s3cr3t"))
+	assert.False(t, CheckBasicAuth(basic("other:s3cr3t"), "admin", "s3cr3t"))
+	assert.False(t, CheckBasicAuth(basic("admin"), "admin", "s3cr3t"))
+	assert.False(t, CheckBasicAuth("Basic not-base64!", "admin", "s3cr3t"))
+	assert.False(t, CheckBasicAuth("Bearer s3cr3t", "admin", "s3cr3t"))
+	assert.False(t, CheckBasicAuth(basic(":"), "", ""), "empty credentials should not match")
+	assert.False(t, CheckBasicAuth(basic("admin:"), "admin", ""))
+}
@@ -1,6 +1,9 @@
 package svchealthcheck
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Checker checks the health of a dependency and return an error if it is not healthy.
 type Checker interface {
@@ -14,3 +17,57 @@ type CheckerFunc func(ctx context.Context) error
 func (c CheckerFunc) Check(ctx context.Context) error {
 	return c(ctx)
 }
+
+// DetailedChecker is an optional interface for Checkers that report structured results. When a Checker implements it,
+// CheckDetailed is called instead of Check.
+type DetailedChecker interface {
+	Checker
+	CheckDetailed(ctx context.Context) CheckResult
+}
+
+// CheckResult is the structured result of a DetailedChecker.
+type CheckResult struct {
+	// Err is the error of the check. It defines whether the check failed, regardless of the Components.
+	Err error
+	// Outcome overrides the outcome derived from Err by OutcomeFromError when set.
+	Outcome Outcome
+	// Duration is reported for Components only, the duration of the top level check is always measured.
+	Duration time.Duration
+	// ObservedValue is the value observed by the check (eg. the number of open connections).
+	ObservedValue interface{}
+	// ObservedUnit is the unit of the ObservedValue.
+	ObservedUnit string
+	// Details holds arbitrary key/value information about the check.
+	Details map[string]interface{}
+	// Components holds the results of the sub-components validated by the check.
+	Components map[string]CheckResult
+}
+
+// DetailedCheckerFunc is a DetailedChecker defined as a function.
+type DetailedCheckerFunc func(ctx context.Context) CheckResult
+
+// Check implements the Checker interface for the DetailedCheckerFunc type.
+func (c DetailedCheckerFunc) Check(ctx context.Context) error {
+	return c(ctx).Err
+}
+
+// CheckDetailed implements the DetailedChecker interface for the DetailedCheckerFunc type.
+func (c DetailedCheckerFunc) CheckDetailed(ctx context.Context) CheckResult {
+	return c(ctx)
+}
+
+// runCheck runs the check, using the DetailedChecker interface when it is available.
+func runCheck(ctx context.Context, check Checker) CheckResult {
+	if dc, ok := check.(DetailedChecker); ok {
+		return dc.CheckDetailed(ctx)
+	}
+	return CheckResult{Err: check.Check(ctx)}
+}
+
+// EffectiveOutcome returns the explicit Outcome of the result or, if none was set, the one derived from Err.
+func (r CheckResult) EffectiveOutcome() Outcome {
+	if r.Outcome != "" {
+		return r.Outcome
+	}
+	return OutcomeFromError(r.Err)
+}
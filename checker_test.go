@@ -23,3 +23,21 @@ func TestCheckerFunc_Check(t *testing.T) {
 	assert.Equal(t, wantErr, gotErr)
 
 }
+
+func TestDetailedCheckerFunc(t *testing.T) {
+	wantErr := errors.New("test error")
+	wantResult := CheckResult{
+		Err:           wantErr,
+		ObservedValue: 42,
+		Components: map[string]CheckResult{
+			"shard1": {Err: wantErr},
+		},
+	}
+	checker := DetailedCheckerFunc(func(ctx context.Context) CheckResult {
+		return wantResult
+	})
+
+	assert.Implements(t, (*DetailedChecker)(nil), checker)
+	assert.Equal(t, wantErr, checker.Check(context.Background()))
+	assert.Equal(t, wantResult, checker.CheckDetailed(context.Background()))
+}
@@ -0,0 +1,199 @@
+package broker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+const (
+	amqpFrameMethod = 1
+	amqpFrameEnd    = 0xCE
+	// amqpMaxFrameSize is the default frame-max of RabbitMQ. The connection methods are far smaller.
+	amqpMaxFrameSize = 128 * 1024
+
+	amqpClassConnection = 10
+
+	amqpMethodStart    = 10
+	amqpMethodStartOk  = 11
+	amqpMethodTune     = 30
+	amqpMethodTuneOk   = 31
+	amqpMethodOpen     = 40
+	amqpMethodOpenOk   = 41
+	amqpMethodClose    = 50
+	amqpMethodCloseOk  = 51
+	amqpReplySuccess   = 200
+	amqpDefaultVHost   = "/"
+	amqpDefaultLocale  = "en_US"
+	amqpPlainMechanism = "PLAIN"
+)
+
+var amqpProtocolHeader = []byte("AMQP\x00\x00\x09\x01")
+
+// AMQPOptions configures the AMQP checker.
+type AMQPOptions struct {
+	Username string
+	Password string
+	// VHost is the virtual host opened by the checker. It defaults to "/".
+	VHost string
+	// TLSConfig enables TLS when set.
+	TLSConfig *tls.Config
+}
+
+// AMQP returns a Checker that opens and closes an AMQP 0-9-1 connection (RabbitMQ) with the broker at addr, using the
+// PLAIN authentication mechanism.
+func AMQP(addr string, opts AMQPOptions) srvhealthcheck.Checker {
+	return srvhealthcheck.CheckerFunc(func(ctx context.Context) error {
+		conn, err := dial(ctx, addr, opts.TLSConfig)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		return amqpHandshake(&amqpConn{w: conn, r: bufio.NewReader(conn)}, opts)
+	})
+}
+
+func amqpHandshake(c *amqpConn, opts AMQPOptions) error {
+	if _, err := c.w.Write(amqpProtocolHeader); err != nil {
+		return err
+	}
+
+	// A server that does not support the protocol version answers with its own protocol header.
+	if peek, err := c.r.Peek(4); err == nil && string(peek) == "AMQP" {
+		header := make([]byte, len(amqpProtocolHeader))
+		_, _ = io.ReadFull(c.r, header)
+		return fmt.Errorf("%w: protocol version not supported, server offered %v", ErrUnexpectedResponse, header[4:])
+	}
+
+	if _, err := c.expect(amqpMethodStart); err != nil {
+		return err
+	}
+
+	var startOk bytes.Buffer
+	_ = binary.Write(&startOk, binary.BigEndian, uint32(0)) // empty client-properties table
+	writeShortStr(&startOk, amqpPlainMechanism)
+	writeLongStr(&startOk, "\x00"+opts.Username+"\x00"+opts.Password)
+	writeShortStr(&startOk, amqpDefaultLocale)
+	if err := c.writeMethod(amqpMethodStartOk, startOk.Bytes()); err != nil {
+		return err
+	}
+
+	tune, err := c.expect(amqpMethodTune)
+	if err != nil {
+		return err
+	}
+	if len(tune) < 8 {
+		return fmt.Errorf("%w: short connection.tune", ErrUnexpectedResponse)
+	}
+	// connection.tune-ok echoes channel-max, frame-max and heartbeat.
+	if err := c.writeMethod(amqpMethodTuneOk, tune[:8]); err != nil {
+		return err
+	}
+
+	vhost := opts.VHost
+	if vhost == "" {
+		vhost = amqpDefaultVHost
+	}
+	var open bytes.Buffer
+	writeShortStr(&open, vhost)
+	writeShortStr(&open, "") // reserved-1
+	open.WriteByte(0)        // reserved-2
+	if err := c.writeMethod(amqpMethodOpen, open.Bytes()); err != nil {
+		return err
+	}
+	if _, err := c.expect(amqpMethodOpenOk); err != nil {
+		return err
+	}
+
+	var closeArgs bytes.Buffer
+	_ = binary.Write(&closeArgs, binary.BigEndian, uint16(amqpReplySuccess))
+	writeShortStr(&closeArgs, "")
+	_ = binary.Write(&closeArgs, binary.BigEndian, uint32(0)) // class-id and method-id
+	if err := c.writeMethod(amqpMethodClose, closeArgs.Bytes()); err != nil {
+		return err
+	}
+	_, err = c.expect(amqpMethodCloseOk)
+	return err
+}
+
+// amqpConn reads and writes AMQP method frames of the connection class on channel 0.
+type amqpConn struct {
+	w io.Writer
+	r *bufio.Reader
+}
+
+func (c *amqpConn) writeMethod(method uint16, args []byte) error {
+	var buf bytes.Buffer
+	buf.WriteByte(amqpFrameMethod)
+	_ = binary.Write(&buf, binary.BigEndian, uint16(0)) // channel
+	_ = binary.Write(&buf, binary.BigEndian, uint32(4+len(args)))
+	_ = binary.Write(&buf, binary.BigEndian, uint16(amqpClassConnection))
+	_ = binary.Write(&buf, binary.BigEndian, method)
+	buf.Write(args)
+	buf.WriteByte(amqpFrameEnd)
+	_, err := c.w.Write(buf.Bytes())
+	return err
+}
+
+// expect reads the next method frame and returns its arguments if it is the given connection method. A
+// connection.close sent by the server is reported as ErrServerError.
+func (c *amqpConn) expect(method uint16) ([]byte, error) {
+	var header [7]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[3:])
+	if size < 4 || size > amqpMaxFrameSize {
+		return nil, fmt.Errorf("%w: invalid frame size %d", ErrUnexpectedResponse, size)
+	}
+	payload := make([]byte, size+1)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return nil, err
+	}
+	if header[0] != amqpFrameMethod || payload[size] != amqpFrameEnd {
+		return nil, fmt.Errorf("%w: invalid frame", ErrUnexpectedResponse)
+	}
+
+	class := binary.BigEndian.Uint16(payload[0:2])
+	gotMethod := binary.BigEndian.Uint16(payload[2:4])
+	args := payload[4:size]
+	if class == amqpClassConnection && gotMethod == amqpMethodClose && method != amqpMethodClose {
+		return nil, amqpCloseError(args)
+	}
+	if class != amqpClassConnection || gotMethod != method {
+		return nil, fmt.Errorf("%w: expected method %d.%d, got %d.%d", ErrUnexpectedResponse, amqpClassConnection, method, class, gotMethod)
+	}
+	return args, nil
+}
+
+func amqpCloseError(args []byte) error {
+	if len(args) < 3 {
+		return fmt.Errorf("%w: connection closed", ErrServerError)
+	}
+	code := binary.BigEndian.Uint16(args[0:2])
+	n := int(args[2])
+	text := ""
+	if len(args) >= 3+n {
+		text = string(args[3 : 3+n])
+	}
+	return fmt.Errorf("%w: %d %s", ErrServerError, code, text)
+}
+
+func writeShortStr(buf *bytes.Buffer, s string) {
+	buf.WriteByte(byte(len(s)))
+	buf.WriteString(s)
+}
+
+func writeLongStr(buf *bytes.Buffer, s string) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(s)))
+	buf.WriteString(s)
+}
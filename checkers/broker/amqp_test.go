@@ -0,0 +1,132 @@
+package broker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAMQP struct {
+	username string
+	password string
+}
+
+func (f *fakeAMQP) handle(conn net.Conn) {
+	c := &amqpConn{w: conn, r: bufio.NewReader(conn)}
+
+	header := make([]byte, len(amqpProtocolHeader))
+	if _, err := io.ReadFull(c.r, header); err != nil || !bytes.Equal(header, amqpProtocolHeader) {
+		return
+	}
+
+	var start bytes.Buffer
+	start.Write([]byte{0, 9})
+	_ = binary.Write(&start, binary.BigEndian, uint32(0))
+	writeLongStr(&start, "PLAIN")
+	writeLongStr(&start, "en_US")
+	if c.writeMethod(amqpMethodStart, start.Bytes()) != nil {
+		return
+	}
+
+	startOk, err := c.expect(amqpMethodStartOk)
+	if err != nil {
+		return
+	}
+	tableSize := binary.BigEndian.Uint32(startOk)
+	rest := startOk[4+tableSize:]
+	rest = rest[1+int(rest[0]):] // mechanism
+	responseSize := binary.BigEndian.Uint32(rest)
+	response := string(rest[4 : 4+responseSize])
+	if response != "\x00"+f.username+"\x00"+f.password {
+		var closeArgs bytes.Buffer
+		_ = binary.Write(&closeArgs, binary.BigEndian, uint16(403))
+		writeShortStr(&closeArgs, "ACCESS_REFUSED")
+		_ = binary.Write(&closeArgs, binary.BigEndian, uint32(0))
+		_ = c.writeMethod(amqpMethodClose, closeArgs.Bytes())
+		return
+	}
+
+	var tune bytes.Buffer
+	_ = binary.Write(&tune, binary.BigEndian, uint16(2047))
+	_ = binary.Write(&tune, binary.BigEndian, uint32(131072))
+	_ = binary.Write(&tune, binary.BigEndian, uint16(60))
+	if c.writeMethod(amqpMethodTune, tune.Bytes()) != nil {
+		return
+	}
+	if _, err := c.expect(amqpMethodTuneOk); err != nil {
+		return
+	}
+	if _, err := c.expect(amqpMethodOpen); err != nil {
+		return
+	}
+	if c.writeMethod(amqpMethodOpenOk, []byte{0}) != nil {
+		return
+	}
+	if _, err := c.expect(amqpMethodClose); err != nil {
+		return
+	}
+	_ = c.writeMethod(amqpMethodCloseOk, nil)
+}
+
+func TestAMQP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	addr := serveTCP(t, (&fakeAMQP{username: "guest", password: "guest"}).handle)
+
+	t.Run("should pass when the connection is opened", func(t *testing.T) {
+		err := AMQP(addr, AMQPOptions{Username: "guest", Password: "guest"}).Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the credentials are refused", func(t *testing.T) {
+		err := AMQP(addr, AMQPOptions{Username: "guest", Password: "wrong"}).Check(ctx)
+		assert.ErrorIs(t, err, ErrServerError)
+		assert.Contains(t, err.Error(), "403 ACCESS_REFUSED")
+	})
+
+	t.Run("should fail when the server does not support the protocol", func(t *testing.T) {
+		addr := serveTCP(t, func(conn net.Conn) {
+			_, _ = conn.Write([]byte("AMQP\x00\x00\x08\x00"))
+		})
+		err := AMQP(addr, AMQPOptions{}).Check(ctx)
+		assert.ErrorIs(t, err, ErrUnexpectedResponse)
+	})
+
+	t.Run("should fail when the frame is too large", func(t *testing.T) {
+		for _, size := range []uint32{amqpMaxFrameSize + 1, 0xFFFFFFFF} {
+			addr := serveTCP(t, func(conn net.Conn) {
+				_, _ = io.ReadFull(conn, make([]byte, len(amqpProtocolHeader)))
+				_, _ = conn.Write([]byte{amqpFrameMethod, 0, 0})
+				_ = binary.Write(conn, binary.BigEndian, size)
+			})
+			err := AMQP(addr, AMQPOptions{}).Check(ctx)
+			assert.ErrorIs(t, err, ErrUnexpectedResponse)
+			assert.ErrorContains(t, err, "invalid frame size")
+		}
+	})
+
+	t.Run("should fail when the server is not reachable", func(t *testing.T) {
+		err := AMQP(closedAddr(t), AMQPOptions{}).Check(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("should fail when the deadline is exceeded", func(t *testing.T) {
+		addr := serveTCP(t, func(conn net.Conn) {
+			_, _ = io.Copy(io.Discard, conn)
+		})
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+		defer cancel()
+		err := AMQP(addr, AMQPOptions{}).Check(ctx)
+		var netErr net.Error
+		assert.ErrorAs(t, err, &netErr)
+		assert.True(t, netErr.Timeout())
+	})
+}
@@ -0,0 +1,41 @@
+// Package broker implements checkers for message brokers by performing the protocol handshake, without depending on
+// the full client libraries.
+package broker
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+)
+
+var (
+	// ErrUnexpectedResponse is returned when the broker answers with something that does not follow the protocol.
+	ErrUnexpectedResponse = errors.New("unexpected broker response")
+	// ErrServerError is returned when the broker explicitly reports an error during the handshake.
+	ErrServerError = errors.New("broker error")
+)
+
+// dial connects to the given address, using TLS if tlsConfig is set, and applies the context deadline to the
+// connection.
+func dial(ctx context.Context, addr string, tlsConfig *tls.Config) (net.Conn, error) {
+	var (
+		conn net.Conn
+		err  error
+	)
+	if tlsConfig != nil {
+		d := tls.Dialer{Config: tlsConfig}
+		conn, err = d.DialContext(ctx, "tcp", addr)
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	return conn, nil
+}
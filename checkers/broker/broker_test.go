@@ -0,0 +1,45 @@
+package broker
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// serveTCP starts a listener on a random local port and calls handle for each accepted connection.
+func serveTCP(t *testing.T, handle func(conn net.Conn)) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = lis.Close()
+	})
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() {
+					_ = conn.Close()
+				}()
+				handle(conn)
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+// closedAddr returns an address where nothing is listening.
+func closedAddr(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+	return addr
+}
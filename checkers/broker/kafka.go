@@ -0,0 +1,175 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+const (
+	kafkaAPIKeyMetadata    = 3
+	kafkaAPIKeyAPIVersions = 18
+	kafkaDefaultClientID   = "svchealthcheck"
+	kafkaMaxResponseSize   = 16 * 1024 * 1024
+
+	// kafkaMinMetadataVersion is the first Metadata version where an empty topic list means no topics, instead of all
+	// of them. kafkaMaxMetadataVersion is the last version without the flexible encoding.
+	kafkaMinMetadataVersion = 1
+	kafkaMaxMetadataVersion = 8
+)
+
+var (
+	// ErrNotEnoughBrokers is returned when the Kafka metadata lists fewer brokers than KafkaOptions.MinBrokers.
+	ErrNotEnoughBrokers = errors.New("not enough kafka brokers")
+)
+
+// KafkaOptions configures the Kafka checker.
+type KafkaOptions struct {
+	// ClientID is sent on the request headers. It defaults to "svchealthcheck".
+	ClientID string
+	// MinBrokers is the minimum number of brokers the cluster metadata must list. It defaults to 1.
+	MinBrokers int
+	// TLSConfig enables TLS when set.
+	TLSConfig *tls.Config
+}
+
+// Kafka returns a Checker that sends an ApiVersions request followed by a Metadata request to the Kafka broker at
+// addr, failing when the broker reports an error or the cluster has fewer than KafkaOptions.MinBrokers brokers. The
+// Metadata request asks for no topics, which requires a broker supporting Metadata v1 (Kafka 0.10 or later).
+func Kafka(addr string, opts KafkaOptions) srvhealthcheck.Checker {
+	clientID := opts.ClientID
+	if clientID == "" {
+		clientID = kafkaDefaultClientID
+	}
+	minBrokers := opts.MinBrokers
+	if minBrokers <= 0 {
+		minBrokers = 1
+	}
+
+	return srvhealthcheck.CheckerFunc(func(ctx context.Context) error {
+		conn, err := dial(ctx, addr, opts.TLSConfig)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		c := &kafkaConn{rw: conn, clientID: clientID}
+
+		resp, err := c.request(kafkaAPIKeyAPIVersions, 0, nil)
+		if err != nil {
+			return err
+		}
+		version, err := kafkaMetadataVersion(resp)
+		if err != nil {
+			return err
+		}
+
+		// Only the brokers are needed, so no topics are requested.
+		body := []byte{0, 0, 0, 0}
+		if version >= 4 {
+			body = append(body, 0) // allow_auto_topic_creation
+		}
+		if version >= 8 {
+			body = append(body, 0, 0) // include_cluster_authorized_operations, include_topic_authorized_operations
+		}
+		resp, err = c.request(kafkaAPIKeyMetadata, version, body)
+		if err != nil {
+			return err
+		}
+		if version >= 3 {
+			if len(resp) < 4 {
+				return fmt.Errorf("%w: short Metadata response", ErrUnexpectedResponse)
+			}
+			resp = resp[4:] // throttle_time_ms
+		}
+		if len(resp) < 4 {
+			return fmt.Errorf("%w: short Metadata response", ErrUnexpectedResponse)
+		}
+		if brokers := int32(binary.BigEndian.Uint32(resp)); int(brokers) < minBrokers {
+			return fmt.Errorf("%w: %d brokers available, %d required", ErrNotEnoughBrokers, brokers, minBrokers)
+		}
+		return nil
+	})
+}
+
+// kafkaMetadataVersion returns the highest Metadata version supported by both the checker and the broker, from the
+// ApiVersions v0 response.
+func kafkaMetadataVersion(resp []byte) (int16, error) {
+	if len(resp) < 6 {
+		return 0, fmt.Errorf("%w: short ApiVersions response", ErrUnexpectedResponse)
+	}
+	if code := int16(binary.BigEndian.Uint16(resp)); code != 0 {
+		return 0, fmt.Errorf("%w: ApiVersions error code %d", ErrServerError, code)
+	}
+	n := int(int32(binary.BigEndian.Uint32(resp[2:])))
+	resp = resp[6:]
+	if n < 0 || len(resp) < n*6 {
+		return 0, fmt.Errorf("%w: short ApiVersions response", ErrUnexpectedResponse)
+	}
+	for i := 0; i < n; i++ {
+		entry := resp[i*6:]
+		if int16(binary.BigEndian.Uint16(entry)) != kafkaAPIKeyMetadata {
+			continue
+		}
+		minVersion := int16(binary.BigEndian.Uint16(entry[2:]))
+		maxVersion := int16(binary.BigEndian.Uint16(entry[4:]))
+		if maxVersion > kafkaMaxMetadataVersion {
+			maxVersion = kafkaMaxMetadataVersion
+		}
+		if maxVersion < kafkaMinMetadataVersion || maxVersion < minVersion {
+			return 0, fmt.Errorf("%w: Metadata versions %d-%d are not supported", ErrUnexpectedResponse, minVersion, maxVersion)
+		}
+		return maxVersion, nil
+	}
+	return 0, fmt.Errorf("%w: Metadata is not supported", ErrUnexpectedResponse)
+}
+
+// kafkaConn sends requests with the non-flexible headers and returns the response bodies, after the correlation id.
+type kafkaConn struct {
+	rw            io.ReadWriter
+	clientID      string
+	correlationID int32
+}
+
+func (c *kafkaConn) request(apiKey, apiVersion int16, body []byte) ([]byte, error) {
+	c.correlationID++
+
+	var req bytes.Buffer
+	_ = binary.Write(&req, binary.BigEndian, apiKey)
+	_ = binary.Write(&req, binary.BigEndian, apiVersion)
+	_ = binary.Write(&req, binary.BigEndian, c.correlationID)
+	_ = binary.Write(&req, binary.BigEndian, int16(len(c.clientID)))
+	req.WriteString(c.clientID)
+	req.Write(body)
+
+	var frame bytes.Buffer
+	_ = binary.Write(&frame, binary.BigEndian, int32(req.Len()))
+	frame.Write(req.Bytes())
+	if _, err := c.rw.Write(frame.Bytes()); err != nil {
+		return nil, err
+	}
+
+	var size int32
+	if err := binary.Read(c.rw, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if size < 4 || size > kafkaMaxResponseSize {
+		return nil, fmt.Errorf("%w: invalid response size %d", ErrUnexpectedResponse, size)
+	}
+	resp := make([]byte, size)
+	if _, err := io.ReadFull(c.rw, resp); err != nil {
+		return nil, err
+	}
+	if got := int32(binary.BigEndian.Uint32(resp)); got != c.correlationID {
+		return nil, fmt.Errorf("%w: correlation id %d, expected %d", ErrUnexpectedResponse, got, c.correlationID)
+	}
+	return resp[4:], nil
+}
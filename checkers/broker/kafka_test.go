@@ -0,0 +1,134 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeKafka struct {
+	brokers        int32
+	apiVersionsErr int16
+	// metadataVersions is the range of Metadata versions supported. It defaults to 0-12.
+	metadataVersions []int16
+	// metadataRequests receives the version and the body of the Metadata requests.
+	metadataRequests chan []byte
+}
+
+func (f *fakeKafka) handle(conn net.Conn) {
+	for {
+		var size int32
+		if binary.Read(conn, binary.BigEndian, &size) != nil {
+			return
+		}
+		req := make([]byte, size)
+		if _, err := io.ReadFull(conn, req); err != nil {
+			return
+		}
+		apiKey := int16(binary.BigEndian.Uint16(req[0:2]))
+		correlationID := binary.BigEndian.Uint32(req[4:8])
+
+		var body bytes.Buffer
+		_ = binary.Write(&body, binary.BigEndian, correlationID)
+		switch apiKey {
+		case kafkaAPIKeyAPIVersions:
+			versions := f.metadataVersions
+			if versions == nil {
+				versions = []int16{0, 12}
+			}
+			_ = binary.Write(&body, binary.BigEndian, f.apiVersionsErr)
+			_ = binary.Write(&body, binary.BigEndian, int32(2))
+			_ = binary.Write(&body, binary.BigEndian, []int16{kafkaAPIKeyAPIVersions, 0, 3})
+			_ = binary.Write(&body, binary.BigEndian, []int16{kafkaAPIKeyMetadata, versions[0], versions[1]})
+		case kafkaAPIKeyMetadata:
+			version := int16(binary.BigEndian.Uint16(req[2:4]))
+			if f.metadataRequests != nil {
+				clientIDLen := int(binary.BigEndian.Uint16(req[8:10]))
+				f.metadataRequests <- append([]byte{byte(version)}, req[10+clientIDLen:]...)
+			}
+			if version >= 3 {
+				_ = binary.Write(&body, binary.BigEndian, int32(0)) // throttle_time_ms
+			}
+			_ = binary.Write(&body, binary.BigEndian, f.brokers)
+			for i := int32(0); i < f.brokers; i++ {
+				_ = binary.Write(&body, binary.BigEndian, i)
+				_ = binary.Write(&body, binary.BigEndian, int16(len("localhost")))
+				body.WriteString("localhost")
+				_ = binary.Write(&body, binary.BigEndian, int32(9092))
+			}
+			_ = binary.Write(&body, binary.BigEndian, int32(0)) // topics
+		default:
+			return
+		}
+
+		_ = binary.Write(conn, binary.BigEndian, int32(body.Len()))
+		if _, err := conn.Write(body.Bytes()); err != nil {
+			return
+		}
+	}
+}
+
+func TestKafka(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	t.Run("should pass when the cluster has enough brokers", func(t *testing.T) {
+		addr := serveTCP(t, (&fakeKafka{brokers: 3}).handle)
+		assert.NoError(t, Kafka(addr, KafkaOptions{}).Check(ctx))
+		assert.NoError(t, Kafka(addr, KafkaOptions{MinBrokers: 3}).Check(ctx))
+	})
+
+	t.Run("should fail when the cluster does not have enough brokers", func(t *testing.T) {
+		addr := serveTCP(t, (&fakeKafka{brokers: 1}).handle)
+		err := Kafka(addr, KafkaOptions{MinBrokers: 2}).Check(ctx)
+		assert.ErrorIs(t, err, ErrNotEnoughBrokers)
+	})
+
+	t.Run("should request no topics with the highest supported Metadata version", func(t *testing.T) {
+		requests := make(chan []byte, 1)
+		addr := serveTCP(t, (&fakeKafka{brokers: 1, metadataRequests: requests}).handle)
+		assert.NoError(t, Kafka(addr, KafkaOptions{}).Check(ctx))
+		// Version 8, an empty topic array and the three flags disabled.
+		assert.Equal(t, []byte{8, 0, 0, 0, 0, 0, 0, 0}, <-requests)
+	})
+
+	t.Run("should use the versions supported by older brokers", func(t *testing.T) {
+		requests := make(chan []byte, 1)
+		addr := serveTCP(t, (&fakeKafka{brokers: 2, metadataVersions: []int16{0, 2}, metadataRequests: requests}).handle)
+		assert.NoError(t, Kafka(addr, KafkaOptions{MinBrokers: 2}).Check(ctx))
+		assert.Equal(t, []byte{2, 0, 0, 0, 0}, <-requests)
+	})
+
+	t.Run("should fail when the broker only supports Metadata v0", func(t *testing.T) {
+		addr := serveTCP(t, (&fakeKafka{brokers: 1, metadataVersions: []int16{0, 0}}).handle)
+		err := Kafka(addr, KafkaOptions{}).Check(ctx)
+		assert.ErrorIs(t, err, ErrUnexpectedResponse)
+	})
+
+	t.Run("should fail when ApiVersions returns an error", func(t *testing.T) {
+		addr := serveTCP(t, (&fakeKafka{brokers: 1, apiVersionsErr: 35}).handle)
+		err := Kafka(addr, KafkaOptions{}).Check(ctx)
+		assert.ErrorIs(t, err, ErrServerError)
+	})
+
+	t.Run("should fail when the server answers with another correlation id", func(t *testing.T) {
+		addr := serveTCP(t, func(conn net.Conn) {
+			_, _ = io.ReadFull(conn, make([]byte, 4))
+			_ = binary.Write(conn, binary.BigEndian, []int32{6, 99})
+			_, _ = conn.Write([]byte{0, 0})
+		})
+		err := Kafka(addr, KafkaOptions{}).Check(ctx)
+		assert.ErrorIs(t, err, ErrUnexpectedResponse)
+	})
+
+	t.Run("should fail when the server is not reachable", func(t *testing.T) {
+		err := Kafka(closedAddr(t), KafkaOptions{}).Check(ctx)
+		assert.Error(t, err)
+	})
+}
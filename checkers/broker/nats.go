@@ -0,0 +1,129 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+// NATSOptions configures the NATS checker.
+type NATSOptions struct {
+	User     string
+	Password string
+	Token    string
+	// TLSConfig is used to upgrade the connection after the server INFO when set.
+	TLSConfig *tls.Config
+}
+
+type natsInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+type natsConnect struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	Protocol int    `json:"protocol"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+// NATS returns a Checker that connects to the NATS server at addr, sends CONNECT and PING, and expects a PONG.
+func NATS(addr string, opts NATSOptions) srvhealthcheck.Checker {
+	return srvhealthcheck.CheckerFunc(func(ctx context.Context) error {
+		conn, err := dial(ctx, addr, nil)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		return natsHandshake(conn, addr, opts)
+	})
+}
+
+func natsHandshake(conn net.Conn, addr string, opts NATSOptions) error {
+	r := bufio.NewReader(conn)
+	line, err := readNATSLine(r)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("%w: expected INFO, got %q", ErrUnexpectedResponse, line)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(line[len("INFO "):]), &info); err != nil {
+		return fmt.Errorf("%w: invalid INFO: %s", ErrUnexpectedResponse, err)
+	}
+
+	var w io.Writer = conn
+	if opts.TLSConfig != nil {
+		cfg := opts.TLSConfig.Clone()
+		if cfg.ServerName == "" {
+			cfg.ServerName, _, _ = net.SplitHostPort(addr)
+		}
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.Handshake(); err != nil {
+			return err
+		}
+		w = tlsConn
+		r = bufio.NewReader(tlsConn)
+	} else if info.TLSRequired {
+		return fmt.Errorf("%w: server requires TLS", ErrServerError)
+	}
+
+	connect, err := json.Marshal(natsConnect{
+		Name:     "svchealthcheck",
+		Lang:     "go",
+		Version:  "1.0.0",
+		Protocol: 1,
+		User:     opts.User,
+		Pass:     opts.Password,
+		Token:    opts.Token,
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		return err
+	}
+
+	for {
+		line, err := readNATSLine(r)
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := io.WriteString(w, "PONG\r\n"); err != nil {
+				return err
+			}
+		case line == "+OK", strings.HasPrefix(line, "INFO "):
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("%w: %s", ErrServerError, strings.TrimSpace(line[len("-ERR"):]))
+		default:
+			return fmt.Errorf("%w: %q", ErrUnexpectedResponse, line)
+		}
+	}
+}
+
+func readNATSLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,142 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNATS struct {
+	token     string
+	tlsConfig *tls.Config
+}
+
+func (f *fakeNATS) handle(conn net.Conn) {
+	_, _ = io.WriteString(conn, `INFO {"server_id":"fake","tls_required":`+boolString(f.tlsConfig != nil)+"}\r\n")
+
+	var rw io.ReadWriter = conn
+	if f.tlsConfig != nil {
+		tlsConn := tls.Server(conn, f.tlsConfig)
+		if tlsConn.Handshake() != nil {
+			return
+		}
+		rw = tlsConn
+	}
+
+	r := bufio.NewReader(rw)
+	line, err := r.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "CONNECT ") {
+		return
+	}
+	var connect natsConnect
+	if json.Unmarshal([]byte(strings.TrimPrefix(line, "CONNECT ")), &connect) != nil {
+		return
+	}
+	if connect.Token != f.token {
+		_, _ = io.WriteString(rw, "-ERR 'Authorization Violation'\r\n")
+		return
+	}
+	if line, err := r.ReadString('\n'); err != nil || line != "PING\r\n" {
+		return
+	}
+	// Servers may ping the client before answering.
+	_, _ = io.WriteString(rw, "PING\r\n")
+	if line, err := r.ReadString('\n'); err != nil || line != "PONG\r\n" {
+		return
+	}
+	_, _ = io.WriteString(rw, "PONG\r\n")
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+func newTestTLSConfigs(t *testing.T) (*tls.Config, *tls.Config) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}},
+		&tls.Config{RootCAs: pool}
+}
+
+func TestNATS(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	t.Run("should pass when the server answers the PING", func(t *testing.T) {
+		addr := serveTCP(t, (&fakeNATS{token: "secret"}).handle)
+		err := NATS(addr, NATSOptions{Token: "secret"}).Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the server reports an error", func(t *testing.T) {
+		addr := serveTCP(t, (&fakeNATS{token: "secret"}).handle)
+		err := NATS(addr, NATSOptions{Token: "wrong"}).Check(ctx)
+		assert.ErrorIs(t, err, ErrServerError)
+		assert.Contains(t, err.Error(), "Authorization Violation")
+	})
+
+	t.Run("should fail when the server is not NATS", func(t *testing.T) {
+		addr := serveTCP(t, func(conn net.Conn) {
+			_, _ = io.WriteString(conn, "HTTP/1.1 400 Bad Request\r\n")
+		})
+		err := NATS(addr, NATSOptions{}).Check(ctx)
+		assert.ErrorIs(t, err, ErrUnexpectedResponse)
+	})
+
+	t.Run("should upgrade the connection to TLS", func(t *testing.T) {
+		serverConfig, clientConfig := newTestTLSConfigs(t)
+		addr := serveTCP(t, (&fakeNATS{tlsConfig: serverConfig}).handle)
+		err := NATS(addr, NATSOptions{TLSConfig: clientConfig}).Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the server requires TLS and it is not configured", func(t *testing.T) {
+		serverConfig, _ := newTestTLSConfigs(t)
+		addr := serveTCP(t, (&fakeNATS{tlsConfig: serverConfig}).handle)
+		err := NATS(addr, NATSOptions{}).Check(ctx)
+		assert.ErrorIs(t, err, ErrServerError)
+	})
+
+	t.Run("should fail when the server is not reachable", func(t *testing.T) {
+		err := NATS(closedAddr(t), NATSOptions{}).Check(ctx)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,131 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+var (
+	// ErrQuorumNotReached is returned by Any and Quorum when not enough child checkers passed.
+	ErrQuorumNotReached = errors.New("quorum not reached")
+)
+
+// All returns a Checker that runs all the given checkers concurrently. Its outcome is the worst outcome of the
+// children, the returned error joins the errors of all children, and the result of each child is reported as a
+// component named after its position.
+func All(checks ...srvhealthcheck.Checker) srvhealthcheck.Checker {
+	return srvhealthcheck.DetailedCheckerFunc(func(ctx context.Context) srvhealthcheck.CheckResult {
+		components := runChildren(ctx, checks)
+		outcomes := make([]srvhealthcheck.Outcome, 0, len(components))
+		for _, c := range components {
+			outcomes = append(outcomes, c.EffectiveOutcome())
+		}
+		return srvhealthcheck.CheckResult{
+			Err:        joinChildErrors(components, nil),
+			Outcome:    srvhealthcheck.WorstOutcome(outcomes...),
+			Components: components,
+		}
+	})
+}
+
+// Any returns a Checker that runs all the given checkers concurrently and passes if at least one of them passes or
+// warns.
+func Any(checks ...srvhealthcheck.Checker) srvhealthcheck.Checker {
+	return Quorum(1, checks...)
+}
+
+// Quorum returns a Checker that runs all the given checkers concurrently and passes if at least n of them passed or
+// warned. Skipped children were not checked, so they do not count towards the quorum. Once the quorum is reached, the
+// other children are ignored and the warnings of the counted children are kept. The result of each child is reported
+// as a component named after its position.
+func Quorum(n int, checks ...srvhealthcheck.Checker) srvhealthcheck.Checker {
+	return srvhealthcheck.DetailedCheckerFunc(func(ctx context.Context) srvhealthcheck.CheckResult {
+		components := runChildren(ctx, checks)
+
+		var (
+			passed   int
+			outcomes []srvhealthcheck.Outcome
+		)
+		for _, c := range components {
+			if o := c.EffectiveOutcome(); quorumCounts(o) {
+				passed++
+				outcomes = append(outcomes, o)
+			}
+		}
+
+		result := srvhealthcheck.CheckResult{
+			ObservedValue: passed,
+			Details: map[string]interface{}{
+				"required": n,
+				"total":    len(checks),
+			},
+			Components: components,
+		}
+		if passed < n {
+			result.Outcome = srvhealthcheck.OutcomeFail
+			result.Err = fmt.Errorf("%w: %d of %d checks passed, %d required: %w", ErrQuorumNotReached, passed, len(checks), n, joinChildErrors(components, nil))
+			return result
+		}
+		result.Outcome = srvhealthcheck.WorstOutcome(outcomes...)
+		result.Err = joinChildErrors(components, quorumCounts)
+		return result
+	})
+}
+
+// quorumCounts returns true if a child with the given outcome counts towards the quorum.
+func quorumCounts(o srvhealthcheck.Outcome) bool {
+	return o == srvhealthcheck.OutcomePass || o == srvhealthcheck.OutcomeWarn
+}
+
+// runChildren runs the given checkers concurrently and returns their results keyed by their position. Panics are
+// recovered and reported as a srvhealthcheck.PanicError.
+func runChildren(ctx context.Context, checks []srvhealthcheck.Checker) map[string]srvhealthcheck.CheckResult {
+	results := make([]srvhealthcheck.CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+	wg.Add(len(checks))
+	for i, check := range checks {
+		go func(i int, check srvhealthcheck.Checker) {
+			defer wg.Done()
+			st := time.Now()
+			defer func() {
+				if r := recover(); r != nil {
+					results[i] = srvhealthcheck.CheckResult{Err: srvhealthcheck.NewPanicError(r)}
+				}
+				results[i].Duration = time.Since(st)
+			}()
+			if dc, ok := check.(srvhealthcheck.DetailedChecker); ok {
+				results[i] = dc.CheckDetailed(ctx)
+			} else {
+				results[i] = srvhealthcheck.CheckResult{Err: check.Check(ctx)}
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	components := make(map[string]srvhealthcheck.CheckResult, len(results))
+	for i, result := range results {
+		components[strconv.Itoa(i)] = result
+	}
+	return components
+}
+
+// joinChildErrors joins the errors of the children, prefixed by their position, whose outcome matches the filter. A nil
+// filter matches every child.
+func joinChildErrors(components map[string]srvhealthcheck.CheckResult, filter func(srvhealthcheck.Outcome) bool) error {
+	errs := make([]error, 0, len(components))
+	for i := 0; i < len(components); i++ {
+		c := components[strconv.Itoa(i)]
+		if c.Err == nil || (filter != nil && !filter(c.EffectiveOutcome())) {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("check %d: %w", i, c.Err))
+	}
+	return errors.Join(errs...)
+}
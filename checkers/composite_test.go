@@ -0,0 +1,171 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+var (
+	errChild1 = errors.New("child 1 failed")
+	errChild2 = errors.New("child 2 failed")
+)
+
+func passing() srvhealthcheck.Checker {
+	return srvhealthcheck.CheckerFunc(func(_ context.Context) error {
+		return nil
+	})
+}
+
+func failing(err error) srvhealthcheck.Checker {
+	return srvhealthcheck.CheckerFunc(func(_ context.Context) error {
+		return err
+	})
+}
+
+func TestAll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should pass when all children pass", func(t *testing.T) {
+		assert.NoError(t, All(passing(), passing()).Check(ctx))
+	})
+
+	t.Run("should pass without children", func(t *testing.T) {
+		assert.NoError(t, All().Check(ctx))
+	})
+
+	t.Run("should join the errors of the failed children", func(t *testing.T) {
+		err := All(failing(errChild1), passing(), failing(errChild2)).Check(ctx)
+		assert.ErrorIs(t, err, errChild1)
+		assert.ErrorIs(t, err, errChild2)
+		assert.Contains(t, err.Error(), "check 0: child 1 failed")
+		assert.Contains(t, err.Error(), "check 2: child 2 failed")
+	})
+
+	t.Run("should report a panic as a failure", func(t *testing.T) {
+		err := All(srvhealthcheck.CheckerFunc(func(_ context.Context) error {
+			panic("boom")
+		})).Check(ctx)
+		assert.ErrorIs(t, err, srvhealthcheck.ErrCheckerPanic)
+		assert.Contains(t, string(srvhealthcheck.PanicStack(err)), "composite_test.go", "the stack of the child should be kept")
+	})
+
+	t.Run("should run the children concurrently", func(t *testing.T) {
+		var running atomic.Int32
+		slow := srvhealthcheck.CheckerFunc(func(_ context.Context) error {
+			running.Add(1)
+			deadline := time.Now().Add(time.Second)
+			for running.Load() < 3 {
+				if time.Now().After(deadline) {
+					return errors.New("not concurrent")
+				}
+				time.Sleep(time.Millisecond)
+			}
+			return nil
+		})
+		assert.NoError(t, All(slow, slow, slow).Check(ctx))
+	})
+}
+
+func TestAny(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should pass when one child passes", func(t *testing.T) {
+		assert.NoError(t, Any(failing(errChild1), passing()).Check(ctx))
+	})
+
+	t.Run("should fail when all children fail", func(t *testing.T) {
+		err := Any(failing(errChild1), failing(errChild2)).Check(ctx)
+		assert.ErrorIs(t, err, ErrQuorumNotReached)
+		assert.ErrorIs(t, err, errChild1)
+		assert.ErrorIs(t, err, errChild2)
+	})
+
+	t.Run("should fail without children", func(t *testing.T) {
+		assert.ErrorIs(t, Any().Check(ctx), ErrQuorumNotReached)
+	})
+}
+
+func TestQuorum(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should pass when the quorum is reached", func(t *testing.T) {
+		assert.NoError(t, Quorum(2, passing(), failing(errChild1), passing()).Check(ctx))
+	})
+
+	t.Run("should fail when the quorum is not reached", func(t *testing.T) {
+		err := Quorum(2, passing(), failing(errChild1), failing(errChild2)).Check(ctx)
+		require.ErrorIs(t, err, ErrQuorumNotReached)
+		assert.Contains(t, err.Error(), "1 of 3 checks passed, 2 required")
+		assert.ErrorIs(t, err, errChild2)
+	})
+}
+
+func TestQuorum_CheckDetailed(t *testing.T) {
+	check := Quorum(2, passing(), failing(errChild1), All(passing(), failing(errChild2)))
+
+	dc, ok := check.(srvhealthcheck.DetailedChecker)
+	require.True(t, ok)
+
+	result := dc.CheckDetailed(context.Background())
+	assert.ErrorIs(t, result.Err, ErrQuorumNotReached)
+	assert.Equal(t, 1, result.ObservedValue)
+	require.Len(t, result.Components, 3)
+	assert.NoError(t, result.Components["0"].Err)
+	assert.ErrorIs(t, result.Components["1"].Err, errChild1)
+	assert.ErrorIs(t, result.Components["2"].Err, errChild2)
+	assert.Len(t, result.Components["2"].Components, 2)
+}
+
+func TestComposite_outcomes(t *testing.T) {
+	ctx := context.Background()
+	warning := failing(fmt.Errorf("%w: slow", srvhealthcheck.ErrWarn))
+
+	t.Run("All should report the worst outcome of the children", func(t *testing.T) {
+		result := All(passing(), warning).(srvhealthcheck.DetailedChecker).CheckDetailed(ctx)
+		assert.Equal(t, srvhealthcheck.OutcomeWarn, result.EffectiveOutcome())
+
+		result = All(warning, failing(errChild1)).(srvhealthcheck.DetailedChecker).CheckDetailed(ctx)
+		assert.Equal(t, srvhealthcheck.OutcomeFail, result.EffectiveOutcome())
+	})
+
+	t.Run("Quorum should count warnings as passed", func(t *testing.T) {
+		result := Quorum(2, warning, passing(), failing(errChild1)).(srvhealthcheck.DetailedChecker).CheckDetailed(ctx)
+		assert.Equal(t, srvhealthcheck.OutcomeWarn, result.EffectiveOutcome())
+		assert.ErrorIs(t, result.Err, srvhealthcheck.ErrWarn)
+		assert.NotErrorIs(t, result.Err, errChild1)
+	})
+
+	t.Run("Quorum should fail when warnings are not enough", func(t *testing.T) {
+		result := Quorum(2, warning, failing(errChild1)).(srvhealthcheck.DetailedChecker).CheckDetailed(ctx)
+		assert.Equal(t, srvhealthcheck.OutcomeFail, result.EffectiveOutcome())
+	})
+
+	skipped := failing(fmt.Errorf("%w: disabled", srvhealthcheck.ErrSkipped))
+
+	t.Run("Quorum should not count skipped children", func(t *testing.T) {
+		result := Quorum(2, skipped, skipped, failing(errChild1)).(srvhealthcheck.DetailedChecker).CheckDetailed(ctx)
+		assert.Equal(t, srvhealthcheck.OutcomeFail, result.EffectiveOutcome())
+		assert.ErrorIs(t, result.Err, ErrQuorumNotReached)
+		assert.Equal(t, 0, result.ObservedValue)
+	})
+
+	t.Run("Quorum should ignore skipped children once reached", func(t *testing.T) {
+		result := Quorum(1, skipped, passing()).(srvhealthcheck.DetailedChecker).CheckDetailed(ctx)
+		assert.Equal(t, srvhealthcheck.OutcomePass, result.EffectiveOutcome())
+		assert.NoError(t, result.Err)
+	})
+
+	t.Run("Any should fail when all children are skipped", func(t *testing.T) {
+		err := Any(skipped, skipped).Check(ctx)
+		assert.ErrorIs(t, err, ErrQuorumNotReached)
+	})
+}
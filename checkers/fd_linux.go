@@ -0,0 +1,24 @@
+//go:build linux
+// +build linux
+
+package checkers
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileDescriptors returns the number of open file descriptors of the current process and its RLIMIT_NOFILE soft limit.
+func fileDescriptors() (uint64, uint64, error) {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var rlimit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlimit); err != nil {
+		return 0, 0, err
+	}
+
+	return uint64(len(entries)), rlimit.Cur, nil
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+// +build !linux
+
+package checkers
+
+func fileDescriptors() (uint64, uint64, error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
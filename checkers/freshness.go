@@ -0,0 +1,66 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+var (
+	// ErrStale is returned when a file or a Heartbeat was not updated within the expected interval.
+	ErrStale = errors.New("stale")
+)
+
+// FileFreshness returns a Checker that fails when the modification time of the file at path is older than maxAge, or
+// when the file cannot be read.
+func FileFreshness(path string, maxAge time.Duration) srvhealthcheck.Checker {
+	return srvhealthcheck.CheckerFunc(func(_ context.Context) error {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if age := time.Since(info.ModTime()); age > maxAge {
+			return fmt.Errorf("%w: %s was last modified %s ago, the limit is %s", ErrStale, path, age.Truncate(time.Millisecond), maxAge)
+		}
+		return nil
+	})
+}
+
+// Heartbeat is a Checker that fails when Beat was not called within maxAge. The application is expected to call Beat
+// from its main loop.
+//
+// The creation of the Heartbeat counts as the first beat.
+type Heartbeat struct {
+	maxAge time.Duration
+	last   atomic.Int64
+}
+
+// NewHeartbeat returns a new Heartbeat that fails when the last beat is older than maxAge.
+func NewHeartbeat(maxAge time.Duration) *Heartbeat {
+	h := &Heartbeat{maxAge: maxAge}
+	h.Beat()
+	return h
+}
+
+// Beat records that the application is alive.
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+}
+
+// LastBeat returns the time of the last beat.
+func (h *Heartbeat) LastBeat() time.Time {
+	return time.Unix(0, h.last.Load())
+}
+
+// Check implements the Checker interface.
+func (h *Heartbeat) Check(_ context.Context) error {
+	if age := time.Since(h.LastBeat()); age > h.maxAge {
+		return fmt.Errorf("%w: last heartbeat was %s ago, the limit is %s", ErrStale, age.Truncate(time.Millisecond), h.maxAge)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package checkers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileFreshness(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "heartbeat")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	t.Run("should pass when the file was recently modified", func(t *testing.T) {
+		err := FileFreshness(path, time.Minute).Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the file is stale", func(t *testing.T) {
+		old := time.Now().Add(-time.Hour)
+		require.NoError(t, os.Chtimes(path, old, old))
+
+		err := FileFreshness(path, time.Minute).Check(ctx)
+		assert.ErrorIs(t, err, ErrStale)
+	})
+
+	t.Run("should fail when the file does not exist", func(t *testing.T) {
+		err := FileFreshness(filepath.Join(t.TempDir(), "missing"), time.Minute).Check(ctx)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestHeartbeat(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should pass right after being created", func(t *testing.T) {
+		h := NewHeartbeat(time.Minute)
+		assert.NoError(t, h.Check(ctx))
+	})
+
+	t.Run("should fail when the last beat is too old", func(t *testing.T) {
+		h := NewHeartbeat(time.Millisecond * 10)
+		time.Sleep(time.Millisecond * 20)
+		assert.ErrorIs(t, h.Check(ctx), ErrStale)
+	})
+
+	t.Run("should pass again after a beat", func(t *testing.T) {
+		h := NewHeartbeat(time.Millisecond * 10)
+		time.Sleep(time.Millisecond * 20)
+		h.Beat()
+		assert.NoError(t, h.Check(ctx))
+		assert.WithinDuration(t, time.Now(), h.LastBeat(), time.Millisecond*10)
+	})
+}
@@ -0,0 +1,60 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+var (
+	// ErrGRPCNotServing is returned when the remote service reports NOT_SERVING.
+	ErrGRPCNotServing = errors.New("grpc service not serving")
+	// ErrGRPCServiceUnknown is returned when the remote server does not know the requested service, either replying
+	// SERVICE_UNKNOWN or the NotFound code.
+	ErrGRPCServiceUnknown = errors.New("grpc service unknown")
+	// ErrGRPCUnknownStatus is returned when the remote service reports a status other than the documented ones. It
+	// wraps srvhealthcheck.ErrUnknown.
+	ErrGRPCUnknownStatus = fmt.Errorf("%w: grpc service status", srvhealthcheck.ErrUnknown)
+)
+
+// GRPCHealth returns a Checker that calls the grpc.health.v1.Health/Check method of the given connection for the
+// given service. An empty service name checks the overall health of the server. The serving status is reported as the
+// observed value.
+func GRPCHealth(conn grpc.ClientConnInterface, service string) srvhealthcheck.Checker {
+	client := healthpb.NewHealthClient(conn)
+	return srvhealthcheck.DetailedCheckerFunc(func(ctx context.Context) srvhealthcheck.CheckResult {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if status.Code(err) == codes.NotFound {
+			// The standard health servers reply NotFound for the services they do not know.
+			return srvhealthcheck.CheckResult{Err: fmt.Errorf("%w: %s", ErrGRPCServiceUnknown, service)}
+		}
+		if err != nil {
+			return srvhealthcheck.CheckResult{Err: fmt.Errorf("grpc health check failed: %w", err)}
+		}
+		return srvhealthcheck.CheckResult{
+			Err:           grpcStatusError(resp.GetStatus()),
+			ObservedValue: resp.GetStatus().String(),
+		}
+	})
+}
+
+// grpcStatusError maps the serving status into the error returned by the GRPCHealth checker.
+func grpcStatusError(status healthpb.HealthCheckResponse_ServingStatus) error {
+	switch status {
+	case healthpb.HealthCheckResponse_SERVING:
+		return nil
+	case healthpb.HealthCheckResponse_NOT_SERVING:
+		return fmt.Errorf("%w: %s", ErrGRPCNotServing, status)
+	case healthpb.HealthCheckResponse_SERVICE_UNKNOWN:
+		return fmt.Errorf("%w: %s", ErrGRPCServiceUnknown, status)
+	default:
+		return fmt.Errorf("%w: %s", ErrGRPCUnknownStatus, status)
+	}
+}
@@ -0,0 +1,112 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func startGRPCHealthServer(t *testing.T) (*health.Server, *grpc.ClientConn) {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return hs, conn
+}
+
+func TestGRPCHealth(t *testing.T) {
+	ctx := context.Background()
+	hs, conn := startGRPCHealthServer(t)
+
+	t.Run("should pass when the overall server is serving", func(t *testing.T) {
+		err := GRPCHealth(conn, "").Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should pass when the service is serving", func(t *testing.T) {
+		hs.SetServingStatus("svc.serving", healthpb.HealthCheckResponse_SERVING)
+		err := GRPCHealth(conn, "svc.serving").Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the service is not serving", func(t *testing.T) {
+		hs.SetServingStatus("svc.down", healthpb.HealthCheckResponse_NOT_SERVING)
+		err := GRPCHealth(conn, "svc.down").Check(ctx)
+		assert.ErrorIs(t, err, ErrGRPCNotServing)
+		assert.Contains(t, err.Error(), "NOT_SERVING")
+	})
+
+	t.Run("should report the serving status", func(t *testing.T) {
+		hs.SetServingStatus("svc.reported", healthpb.HealthCheckResponse_SERVING)
+		result := GRPCHealth(conn, "svc.reported").(srvhealthcheck.DetailedChecker).CheckDetailed(ctx)
+		assert.NoError(t, result.Err)
+		assert.Equal(t, "SERVING", result.ObservedValue)
+	})
+
+	t.Run("should fail when the service is unknown to the server", func(t *testing.T) {
+		hs.SetServingStatus("svc.unknown", healthpb.HealthCheckResponse_SERVICE_UNKNOWN)
+		err := GRPCHealth(conn, "svc.unknown").Check(ctx)
+		assert.ErrorIs(t, err, ErrGRPCServiceUnknown)
+	})
+
+	t.Run("should fail when the service is not registered", func(t *testing.T) {
+		err := GRPCHealth(conn, "svc.missing").Check(ctx)
+		assert.ErrorIs(t, err, ErrGRPCServiceUnknown)
+		assert.Contains(t, err.Error(), "svc.missing")
+	})
+
+	t.Run("should fail when the server is not reachable", func(t *testing.T) {
+		conn, err := grpc.NewClient("passthrough:///unreachable",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return nil, errors.New("connection refused")
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = conn.Close()
+		})
+
+		ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		err = GRPCHealth(conn, "").Check(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "grpc health check failed")
+		assert.NotErrorIs(t, err, ErrGRPCServiceUnknown)
+	})
+}
+
+func Test_grpcStatusError(t *testing.T) {
+	err := grpcStatusError(healthpb.HealthCheckResponse_UNKNOWN)
+	assert.ErrorIs(t, err, ErrGRPCUnknownStatus)
+}
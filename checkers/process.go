@@ -0,0 +1,97 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+var (
+	// ErrThresholdExceeded is returned by the process checkers when the observed value is above the configured limit.
+	ErrThresholdExceeded = errors.New("threshold exceeded")
+	// ErrUnsupportedPlatform is returned by checkers that cannot run on the current operating system.
+	ErrUnsupportedPlatform = errors.New("unsupported platform")
+)
+
+// MemoryLimits defines the thresholds used by the Memory checker. A zero value disables the corresponding check.
+type MemoryLimits struct {
+	// MaxHeapAlloc is the maximum number of bytes of allocated heap objects.
+	MaxHeapAlloc uint64
+	// MaxSys is the maximum number of bytes obtained from the OS.
+	MaxSys uint64
+}
+
+// Memory returns a Checker that reads the runtime.MemStats and fails when any of the given limits is exceeded.
+func Memory(limits MemoryLimits) srvhealthcheck.Checker {
+	return srvhealthcheck.CheckerFunc(func(_ context.Context) error {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		if limits.MaxHeapAlloc > 0 && m.HeapAlloc > limits.MaxHeapAlloc {
+			return fmt.Errorf("%w: heap alloc %d bytes is above %d bytes", ErrThresholdExceeded, m.HeapAlloc, limits.MaxHeapAlloc)
+		}
+		if limits.MaxSys > 0 && m.Sys > limits.MaxSys {
+			return fmt.Errorf("%w: sys memory %d bytes is above %d bytes", ErrThresholdExceeded, m.Sys, limits.MaxSys)
+		}
+		return nil
+	})
+}
+
+// Goroutines returns a Checker that fails when the number of running goroutines is above max.
+func Goroutines(max int) srvhealthcheck.Checker {
+	return srvhealthcheck.CheckerFunc(func(_ context.Context) error {
+		n := runtime.NumGoroutine()
+		if n > max {
+			return fmt.Errorf("%w: %d goroutines running, the limit is %d", ErrThresholdExceeded, n, max)
+		}
+		return nil
+	})
+}
+
+// GCPause returns a Checker that fails when the given percentile (0-100) of the recent GC pauses is above max.
+func GCPause(percentile float64, max time.Duration) srvhealthcheck.Checker {
+	return srvhealthcheck.CheckerFunc(func(_ context.Context) error {
+		if percentile < 0 || percentile > 100 {
+			return fmt.Errorf("invalid percentile %v", percentile)
+		}
+		stats := debug.GCStats{
+			// 101 quantiles means PauseQuantiles[i] is the i-th percentile.
+			PauseQuantiles: make([]time.Duration, 101),
+		}
+		debug.ReadGCStats(&stats)
+		if stats.NumGC == 0 {
+			return nil
+		}
+		pause := stats.PauseQuantiles[int(math.Round(percentile))]
+		if pause > max {
+			return fmt.Errorf("%w: p%v GC pause %s is above %s", ErrThresholdExceeded, percentile, pause, max)
+		}
+		return nil
+	})
+}
+
+// FileDescriptors returns a Checker that fails when the ratio between the open file descriptors and the RLIMIT_NOFILE
+// soft limit is above maxRatio (0-1).
+//
+// It is only supported on Linux, other platforms always return ErrUnsupportedPlatform.
+func FileDescriptors(maxRatio float64) srvhealthcheck.Checker {
+	return srvhealthcheck.CheckerFunc(func(_ context.Context) error {
+		open, limit, err := fileDescriptors()
+		if err != nil {
+			return err
+		}
+		if limit == 0 {
+			return nil
+		}
+		ratio := float64(open) / float64(limit)
+		if ratio > maxRatio {
+			return fmt.Errorf("%w: %d of %d file descriptors in use", ErrThresholdExceeded, open, limit)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,92 @@
+package checkers
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should pass when the limits are not exceeded", func(t *testing.T) {
+		err := Memory(MemoryLimits{MaxHeapAlloc: 1 << 40, MaxSys: 1 << 40}).Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should pass when no limits are set", func(t *testing.T) {
+		err := Memory(MemoryLimits{}).Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the heap alloc is above the limit", func(t *testing.T) {
+		err := Memory(MemoryLimits{MaxHeapAlloc: 1}).Check(ctx)
+		assert.ErrorIs(t, err, ErrThresholdExceeded)
+		assert.Contains(t, err.Error(), "heap alloc")
+	})
+
+	t.Run("should fail when the sys memory is above the limit", func(t *testing.T) {
+		err := Memory(MemoryLimits{MaxSys: 1}).Check(ctx)
+		assert.ErrorIs(t, err, ErrThresholdExceeded)
+		assert.Contains(t, err.Error(), "sys memory")
+	})
+}
+
+func TestGoroutines(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should pass when the number of goroutines is below the limit", func(t *testing.T) {
+		err := Goroutines(runtime.NumGoroutine() + 100).Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the number of goroutines is above the limit", func(t *testing.T) {
+		err := Goroutines(0).Check(ctx)
+		assert.ErrorIs(t, err, ErrThresholdExceeded)
+	})
+}
+
+func TestGCPause(t *testing.T) {
+	ctx := context.Background()
+	runtime.GC()
+
+	t.Run("should pass when the pause is below the limit", func(t *testing.T) {
+		err := GCPause(99, time.Hour).Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the pause is above the limit", func(t *testing.T) {
+		err := GCPause(100, -1).Check(ctx)
+		assert.ErrorIs(t, err, ErrThresholdExceeded)
+	})
+
+	t.Run("should fail when the percentile is invalid", func(t *testing.T) {
+		err := GCPause(101, time.Hour).Check(ctx)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrThresholdExceeded)
+	})
+}
+
+func TestFileDescriptors(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Run("should fail on unsupported platforms", func(t *testing.T) {
+			err := FileDescriptors(1).Check(context.Background())
+			assert.ErrorIs(t, err, ErrUnsupportedPlatform)
+		})
+		return
+	}
+
+	t.Run("should pass when the ratio is below the limit", func(t *testing.T) {
+		err := FileDescriptors(1).Check(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the ratio is above the limit", func(t *testing.T) {
+		err := FileDescriptors(0).Check(context.Background())
+		assert.ErrorIs(t, err, ErrThresholdExceeded)
+	})
+}
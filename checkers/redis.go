@@ -0,0 +1,191 @@
+package checkers
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+var (
+	// ErrRedisUnexpectedReply is returned when the Redis server answers with something the checker did not expect.
+	ErrRedisUnexpectedReply = errors.New("unexpected redis reply")
+	// ErrRedisReplication is returned when the replication information does not match the RedisOptions.
+	ErrRedisReplication = errors.New("redis replication check failed")
+)
+
+// redisMaxBulkLength caps the bulk strings read from the server. The replies of PING and INFO replication are a few
+// KiB at most.
+const redisMaxBulkLength = 4 * 1024 * 1024
+
+// RedisOptions configures the Redis checker.
+type RedisOptions struct {
+	// Username is used on the AUTH command, together with Password. Leave it empty for the legacy AUTH.
+	Username string
+	// Password enables the AUTH command when set.
+	Password string
+	// TLSConfig enables TLS when set.
+	TLSConfig *tls.Config
+	// Role, when set, is compared against the role reported by INFO replication (master or slave).
+	Role string
+	// RequireMasterLink fails the check when a replica reports master_link_status different from up.
+	RequireMasterLink bool
+}
+
+// Redis returns a Checker that connects to the Redis server at addr and issues a PING. When RedisOptions.Role or
+// RedisOptions.RequireMasterLink are set, it also issues INFO replication to verify the replication state.
+//
+// Each check opens a new connection, using the deadline of the given context.
+func Redis(addr string, opts RedisOptions) srvhealthcheck.Checker {
+	return srvhealthcheck.CheckerFunc(func(ctx context.Context) error {
+		conn, err := redisDial(ctx, addr, opts.TLSConfig)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetDeadline(deadline)
+		}
+
+		c := &respConn{w: conn, r: bufio.NewReader(conn)}
+
+		if opts.Password != "" {
+			args := []string{"AUTH", opts.Password}
+			if opts.Username != "" {
+				args = []string{"AUTH", opts.Username, opts.Password}
+			}
+			if _, err := c.do(args...); err != nil {
+				return fmt.Errorf("redis auth failed: %w", err)
+			}
+		}
+
+		pong, err := c.do("PING")
+		if err != nil {
+			return fmt.Errorf("redis ping failed: %w", err)
+		}
+		if pong != "PONG" {
+			return fmt.Errorf("%w: %q", ErrRedisUnexpectedReply, pong)
+		}
+
+		if opts.Role == "" && !opts.RequireMasterLink {
+			return nil
+		}
+
+		info, err := c.do("INFO", "replication")
+		if err != nil {
+			return fmt.Errorf("redis info failed: %w", err)
+		}
+		return checkRedisReplication(parseRedisInfo(info), opts)
+	})
+}
+
+func redisDial(ctx context.Context, addr string, tlsConfig *tls.Config) (net.Conn, error) {
+	if tlsConfig != nil {
+		d := tls.Dialer{Config: tlsConfig}
+		return d.DialContext(ctx, "tcp", addr)
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", addr)
+}
+
+func checkRedisReplication(info map[string]string, opts RedisOptions) error {
+	role := info["role"]
+	if opts.Role != "" && role != opts.Role {
+		return fmt.Errorf("%w: role is %q, expected %q", ErrRedisReplication, role, opts.Role)
+	}
+	if opts.RequireMasterLink && role == "slave" {
+		if status := info["master_link_status"]; status != "up" {
+			return fmt.Errorf("%w: master_link_status is %q", ErrRedisReplication, status)
+		}
+	}
+	return nil
+}
+
+// parseRedisInfo parses the output of the INFO command into a map.
+func parseRedisInfo(info string) map[string]string {
+	r := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// respConn is a minimal RESP (REdis Serialization Protocol) client that only supports the replies needed by the
+// Redis checker.
+type respConn struct {
+	w io.Writer
+	r *bufio.Reader
+}
+
+// do sends a command and reads its reply. Simple strings, integers and bulk strings are returned as strings, error
+// replies are returned as errors.
+func (c *respConn) do(args ...string) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.w, sb.String()); err != nil {
+		return "", err
+	}
+	return c.readReply()
+}
+
+func (c *respConn) readReply() (string, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return "", err
+	}
+	if line == "" {
+		return "", fmt.Errorf("%w: empty line", ErrRedisUnexpectedReply)
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", errors.New(line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("%w: invalid bulk length %q", ErrRedisUnexpectedReply, line)
+		}
+		if n < 0 {
+			return "", nil
+		}
+		if n > redisMaxBulkLength {
+			return "", fmt.Errorf("%w: bulk length %d exceeds %d", ErrRedisUnexpectedReply, n, redisMaxBulkLength)
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			return "", err
+		}
+		return string(buf[:n]), nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrRedisUnexpectedReply, line)
+	}
+}
+
+func (c *respConn) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(line, "\r\n"), nil
+}
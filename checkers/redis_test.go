@@ -0,0 +1,174 @@
+package checkers
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRedis struct {
+	password string
+	info     string
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	authenticated := f.password == ""
+	for {
+		args, err := readRESPCommand(r)
+		if err != nil {
+			return
+		}
+		var reply string
+		switch strings.ToUpper(args[0]) {
+		case "AUTH":
+			if args[len(args)-1] == f.password {
+				authenticated = true
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid username-password pair\r\n"
+			}
+		case "PING":
+			if authenticated {
+				reply = "+PONG\r\n"
+			} else {
+				reply = "-NOAUTH Authentication required.\r\n"
+			}
+		case "INFO":
+			reply = fmt.Sprintf("$%d\r\n%s\r\n", len(f.info), f.info)
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readRESPCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		if _, err := r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func TestRedis(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	t.Run("should pass when the server answers the PING", func(t *testing.T) {
+		addr := serveTCP(t, listenTCP(t), (&fakeRedis{}).handle)
+		err := Redis(addr, RedisOptions{}).Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should authenticate before the PING", func(t *testing.T) {
+		addr := serveTCP(t, listenTCP(t), (&fakeRedis{password: "secret"}).handle)
+		err := Redis(addr, RedisOptions{Username: "default", Password: "secret"}).Check(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail when the authentication fails", func(t *testing.T) {
+		addr := serveTCP(t, listenTCP(t), (&fakeRedis{password: "secret"}).handle)
+		err := Redis(addr, RedisOptions{Password: "wrong"}).Check(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "WRONGPASS")
+	})
+
+	t.Run("should fail when the server requires authentication", func(t *testing.T) {
+		addr := serveTCP(t, listenTCP(t), (&fakeRedis{password: "secret"}).handle)
+		err := Redis(addr, RedisOptions{}).Check(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "NOAUTH")
+	})
+
+	t.Run("should fail when the server is not reachable", func(t *testing.T) {
+		lis := listenTCP(t)
+		addr := lis.Addr().String()
+		require.NoError(t, lis.Close())
+		err := Redis(addr, RedisOptions{}).Check(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("should verify the replication role", func(t *testing.T) {
+		addr := serveTCP(t, listenTCP(t), (&fakeRedis{info: "# Replication\r\nrole:master\r\nconnected_slaves:0\r\n"}).handle)
+		assert.NoError(t, Redis(addr, RedisOptions{Role: "master"}).Check(ctx))
+		assert.ErrorIs(t, Redis(addr, RedisOptions{Role: "slave"}).Check(ctx), ErrRedisReplication)
+	})
+
+	t.Run("should verify the master link status", func(t *testing.T) {
+		up := serveTCP(t, listenTCP(t), (&fakeRedis{info: "# Replication\r\nrole:slave\r\nmaster_link_status:up\r\n"}).handle)
+		assert.NoError(t, Redis(up, RedisOptions{RequireMasterLink: true}).Check(ctx))
+
+		down := serveTCP(t, listenTCP(t), (&fakeRedis{info: "# Replication\r\nrole:slave\r\nmaster_link_status:down\r\n"}).handle)
+		assert.ErrorIs(t, Redis(down, RedisOptions{RequireMasterLink: true}).Check(ctx), ErrRedisReplication)
+	})
+
+	t.Run("should connect using TLS", func(t *testing.T) {
+		cert, leaf := newTestCertificate(t, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+		lis := tls.NewListener(listenTCP(t), &tls.Config{Certificates: []tls.Certificate{cert}})
+		addr := serveTCP(t, lis, (&fakeRedis{}).handle)
+
+		pool := x509.NewCertPool()
+		pool.AddCert(leaf)
+		err := Redis(addr, RedisOptions{TLSConfig: &tls.Config{RootCAs: pool, ServerName: "localhost"}}).Check(ctx)
+		assert.NoError(t, err)
+	})
+}
+
+func Test_respConn_readReply(t *testing.T) {
+	t.Run("should read a nil bulk string", func(t *testing.T) {
+		c := &respConn{r: bufio.NewReader(strings.NewReader("$-1\r\n"))}
+		got, err := c.readReply()
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("should read an integer", func(t *testing.T) {
+		c := &respConn{r: bufio.NewReader(strings.NewReader(":42\r\n"))}
+		got, err := c.readReply()
+		require.NoError(t, err)
+		assert.Equal(t, "42", got)
+	})
+
+	t.Run("should fail on bulk strings above the limit", func(t *testing.T) {
+		for _, n := range []string{strconv.Itoa(redisMaxBulkLength + 1), "9223372036854775807"} {
+			c := &respConn{r: bufio.NewReader(strings.NewReader("$" + n + "\r\n"))}
+			_, err := c.readReply()
+			assert.ErrorIs(t, err, ErrRedisUnexpectedReply)
+		}
+	})
+
+	t.Run("should fail on unsupported replies", func(t *testing.T) {
+		c := &respConn{r: bufio.NewReader(strings.NewReader("*1\r\n"))}
+		_, err := c.readReply()
+		assert.ErrorIs(t, err, ErrRedisUnexpectedReply)
+	})
+}
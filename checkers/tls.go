@@ -0,0 +1,140 @@
+package checkers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+var (
+	// ErrCertificateExpired is returned when the certificate is expired or not yet valid.
+	ErrCertificateExpired = errors.New("certificate expired")
+	// ErrCertificateExpiringSoon is returned when the certificate expires within TLSCertificateOptions.WarnBefore. It
+	// wraps srvhealthcheck.ErrWarn, so it is reported as a warning.
+	ErrCertificateExpiringSoon = fmt.Errorf("%w: certificate expiring soon", srvhealthcheck.ErrWarn)
+	// ErrCertificateUnverified is returned when the certificate chain does not verify against the configured pool.
+	ErrCertificateUnverified = errors.New("certificate chain not verified")
+)
+
+// TLSCertificateOptions configures the TLSCertificate checker. Either CertFile and KeyFile, or Addr must be set.
+type TLSCertificateOptions struct {
+	// CertFile and KeyFile point to a local PEM encoded certificate/key pair.
+	CertFile string
+	KeyFile  string
+	// Addr is the host:port of a remote endpoint whose presented chain is checked.
+	Addr string
+	// ServerName is used for SNI and hostname verification of the remote endpoint. It defaults to the host of Addr.
+	ServerName string
+	// RootCAs is the pool the chain is verified against. Remote chains are verified against the system pool when it
+	// is nil, local pairs are only verified when it is set.
+	RootCAs *x509.CertPool
+	// WarnBefore makes the check warn with ErrCertificateExpiringSoon when the certificate expires within it.
+	WarnBefore time.Duration
+}
+
+// TLSCertificate returns a Checker that validates the expiration and the chain of a local certificate/key pair or of
+// the chain presented by a remote endpoint. The subject and the expiration of the leaf certificate are reported as
+// details.
+func TLSCertificate(opts TLSCertificateOptions) srvhealthcheck.Checker {
+	return srvhealthcheck.DetailedCheckerFunc(func(ctx context.Context) srvhealthcheck.CheckResult {
+		chain, verifyOpts, err := loadCertificateChain(ctx, opts)
+		if err != nil {
+			return srvhealthcheck.CheckResult{Err: err}
+		}
+		result := srvhealthcheck.CheckResult{
+			Err: checkCertificateChain(chain, verifyOpts, opts.WarnBefore, time.Now()),
+		}
+		if len(chain) > 0 {
+			result.Details = map[string]interface{}{
+				"subject":  chain[0].Subject.String(),
+				"notAfter": chain[0].NotAfter.Format(time.RFC3339),
+			}
+		}
+		return result
+	})
+}
+
+// loadCertificateChain returns the chain to be checked and how it should be verified. A nil x509.VerifyOptions means
+// the chain should not be verified.
+func loadCertificateChain(ctx context.Context, opts TLSCertificateOptions) ([]*x509.Certificate, *x509.VerifyOptions, error) {
+	switch {
+	case opts.Addr != "":
+		serverName := opts.ServerName
+		if serverName == "" {
+			host, _, err := net.SplitHostPort(opts.Addr)
+			if err != nil {
+				return nil, nil, err
+			}
+			serverName = host
+		}
+		d := tls.Dialer{
+			Config: &tls.Config{
+				ServerName: serverName,
+				// The chain is verified by checkCertificateChain so expired certificates can be reported as such
+				// instead of a handshake failure.
+				InsecureSkipVerify: true, // #nosec G402
+			},
+		}
+		conn, err := d.DialContext(ctx, "tcp", opts.Addr)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer func() {
+			_ = conn.Close()
+		}()
+		chain := conn.(*tls.Conn).ConnectionState().PeerCertificates
+		return chain, &x509.VerifyOptions{Roots: opts.RootCAs, DNSName: serverName}, nil
+	case opts.CertFile != "":
+		pair, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		chain := make([]*x509.Certificate, 0, len(pair.Certificate))
+		for _, der := range pair.Certificate {
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, nil, err
+			}
+			chain = append(chain, cert)
+		}
+		if opts.RootCAs == nil {
+			return chain, nil, nil
+		}
+		return chain, &x509.VerifyOptions{Roots: opts.RootCAs, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}, nil
+	default:
+		return nil, nil, errors.New("either a certificate file or an address must be provided")
+	}
+}
+
+func checkCertificateChain(chain []*x509.Certificate, verifyOpts *x509.VerifyOptions, warnBefore time.Duration, now time.Time) error {
+	if len(chain) == 0 {
+		return fmt.Errorf("%w: no certificates found", ErrCertificateUnverified)
+	}
+
+	leaf := chain[0]
+	if now.After(leaf.NotAfter) || now.Before(leaf.NotBefore) {
+		return fmt.Errorf("%w: %s is valid from %s to %s", ErrCertificateExpired, leaf.Subject, leaf.NotBefore.Format(time.RFC3339), leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	if verifyOpts != nil {
+		verifyOpts.CurrentTime = now
+		verifyOpts.Intermediates = x509.NewCertPool()
+		for _, cert := range chain[1:] {
+			verifyOpts.Intermediates.AddCert(cert)
+		}
+		if _, err := leaf.Verify(*verifyOpts); err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrCertificateUnverified, leaf.Subject, err)
+		}
+	}
+
+	if warnBefore > 0 && leaf.NotAfter.Sub(now) < warnBefore {
+		return fmt.Errorf("%w: %s expires at %s", ErrCertificateExpiringSoon, leaf.Subject, leaf.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
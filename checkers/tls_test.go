@@ -0,0 +1,132 @@
+package checkers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	srvhealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func writeTestKeyPair(t *testing.T, cert tls.Certificate) (string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	keyDER, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func serveTLS(t *testing.T, cert tls.Certificate) string {
+	t.Helper()
+
+	lis := tls.NewListener(listenTCP(t), &tls.Config{Certificates: []tls.Certificate{cert}})
+	return serveTCP(t, lis, func(conn net.Conn) {
+		_ = conn.(*tls.Conn).Handshake()
+	})
+}
+
+func TestTLSCertificate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	now := time.Now()
+	valid, validLeaf := newTestCertificate(t, now.Add(-time.Hour), now.Add(time.Hour*24*90))
+	expiring, _ := newTestCertificate(t, now.Add(-time.Hour), now.Add(time.Hour*24))
+	expired, _ := newTestCertificate(t, now.Add(-time.Hour*48), now.Add(-time.Hour*24))
+	other, otherLeaf := newTestCertificate(t, now.Add(-time.Hour), now.Add(time.Hour*24*90))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(validLeaf)
+
+	t.Run("local", func(t *testing.T) {
+		t.Run("should pass when the certificate is valid", func(t *testing.T) {
+			certFile, keyFile := writeTestKeyPair(t, valid)
+			err := TLSCertificate(TLSCertificateOptions{CertFile: certFile, KeyFile: keyFile, RootCAs: pool, WarnBefore: time.Hour * 24 * 30}).Check(ctx)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should fail when the certificate expires soon", func(t *testing.T) {
+			certFile, keyFile := writeTestKeyPair(t, expiring)
+			err := TLSCertificate(TLSCertificateOptions{CertFile: certFile, KeyFile: keyFile, WarnBefore: time.Hour * 24 * 30}).Check(ctx)
+			assert.ErrorIs(t, err, ErrCertificateExpiringSoon)
+			assert.ErrorIs(t, err, srvhealthcheck.ErrWarn)
+			assert.Contains(t, err.Error(), "CN=localhost")
+		})
+
+		t.Run("should fail when the certificate is expired", func(t *testing.T) {
+			certFile, keyFile := writeTestKeyPair(t, expired)
+			err := TLSCertificate(TLSCertificateOptions{CertFile: certFile, KeyFile: keyFile}).Check(ctx)
+			assert.ErrorIs(t, err, ErrCertificateExpired)
+		})
+
+		t.Run("should fail when the chain does not verify", func(t *testing.T) {
+			certFile, keyFile := writeTestKeyPair(t, other)
+			err := TLSCertificate(TLSCertificateOptions{CertFile: certFile, KeyFile: keyFile, RootCAs: pool}).Check(ctx)
+			assert.ErrorIs(t, err, ErrCertificateUnverified)
+		})
+
+		t.Run("should fail when the files do not exist", func(t *testing.T) {
+			err := TLSCertificate(TLSCertificateOptions{CertFile: "missing.pem", KeyFile: "missing.pem"}).Check(ctx)
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	})
+
+	t.Run("remote", func(t *testing.T) {
+		t.Run("should pass when the presented certificate is valid", func(t *testing.T) {
+			addr := serveTLS(t, valid)
+			err := TLSCertificate(TLSCertificateOptions{Addr: addr, ServerName: "localhost", RootCAs: pool}).Check(ctx)
+			assert.NoError(t, err)
+		})
+
+		t.Run("should report the subject and the expiration", func(t *testing.T) {
+			addr := serveTLS(t, valid)
+			check := TLSCertificate(TLSCertificateOptions{Addr: addr, ServerName: "localhost", RootCAs: pool})
+			result := check.(srvhealthcheck.DetailedChecker).CheckDetailed(ctx)
+			require.NoError(t, result.Err)
+			assert.Equal(t, "CN=localhost", result.Details["subject"])
+			assert.Equal(t, validLeaf.NotAfter.Format(time.RFC3339), result.Details["notAfter"])
+		})
+
+		t.Run("should fail when the presented certificate is expired", func(t *testing.T) {
+			addr := serveTLS(t, expired)
+			err := TLSCertificate(TLSCertificateOptions{Addr: addr, ServerName: "localhost", RootCAs: pool}).Check(ctx)
+			assert.ErrorIs(t, err, ErrCertificateExpired)
+		})
+
+		t.Run("should fail when the presented chain does not verify", func(t *testing.T) {
+			addr := serveTLS(t, other)
+			err := TLSCertificate(TLSCertificateOptions{Addr: addr, ServerName: "localhost", RootCAs: pool}).Check(ctx)
+			assert.ErrorIs(t, err, ErrCertificateUnverified)
+		})
+
+		t.Run("should verify against the configured pool", func(t *testing.T) {
+			otherPool := x509.NewCertPool()
+			otherPool.AddCert(otherLeaf)
+			addr := serveTLS(t, other)
+			err := TLSCertificate(TLSCertificateOptions{Addr: addr, ServerName: "localhost", RootCAs: otherPool}).Check(ctx)
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("should fail when nothing is configured", func(t *testing.T) {
+		err := TLSCertificate(TLSCertificateOptions{}).Check(ctx)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,63 @@
+// Command healthprobe queries the health endpoints of a service and exits with status 0 when it is healthy, or 1
+// otherwise. It is meant for Docker HEALTHCHECK instructions and Kubernetes exec probes in images without curl:
+//
+//	HEALTHCHECK CMD ["/healthprobe", "--ready", "--addr", "unix:///var/run/service.sock"]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+	"github.com/jamillosantos/services-healthcheck/probe"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command with the given arguments and returns its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("healthprobe", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		addr    = flags.String("addr", "localhost:8082", "address of the service, either host:port, an http(s) URL or unix:///path/to/socket")
+		ready   = flags.Bool("ready", false, "query the ready endpoint")
+		live    = flags.Bool("live", false, "query the health endpoint (default)")
+		timeout = flags.Duration("timeout", 5*time.Second, "timeout of the request")
+		verbose = flags.Bool("verbose", false, "print the result of each check")
+	)
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if *ready && *live {
+		fmt.Fprintln(stderr, "healthprobe: --ready and --live cannot be used together")
+		return 1
+	}
+
+	kind := svchealthcheck.KindHealth
+	if *ready {
+		kind = svchealthcheck.KindReady
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	r, err := probe.Probe(ctx, *addr, kind)
+	if err != nil {
+		fmt.Fprintf(stderr, "healthprobe: %s\n", err)
+		return 1
+	}
+	if err := probe.Print(stdout, kind, r, *verbose); err != nil {
+		fmt.Fprintf(stderr, "healthprobe: %s\n", err)
+		return 1
+	}
+	if !probe.Healthy(r) {
+		return 1
+	}
+	return 0
+}
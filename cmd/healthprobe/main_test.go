@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func Test_run(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(svchealthcheck.HealthPath, func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(svchealthcheck.CheckResponse{
+			Status:  "OK",
+			Outcome: svchealthcheck.OutcomePass,
+			Checks: map[string]svchealthcheck.CheckResponseEntry{
+				"database": {Outcome: svchealthcheck.OutcomePass},
+			},
+		})
+	})
+	mux.HandleFunc(svchealthcheck.ReadyPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(svchealthcheck.CheckResponse{Status: "Service Unavailable", Outcome: svchealthcheck.OutcomeFail})
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	t.Run("should exit with 0 when healthy", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"--addr", srv.URL, "--live", "--verbose"}, &stdout, &stderr)
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "health: OK (200, pass)\n  [v] database pass\n", stdout.String())
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("should exit with 1 when not ready", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"--addr", srv.URL, "--ready"}, &stdout, &stderr)
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "ready: Service Unavailable (503, fail)\n", stdout.String())
+	})
+
+	t.Run("should exit with 1 when the service is not reachable", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"--addr", "unix:///nonexistent/health.sock", "--timeout", "1s"}, &stdout, &stderr)
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr.String(), "healthprobe:")
+	})
+
+	t.Run("should exit with 1 on invalid flags", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 1, run([]string{"--unknown"}, &stdout, &stderr))
+		assert.Equal(t, 1, run([]string{"--ready", "--live"}, &stdout, &stderr))
+	})
+}
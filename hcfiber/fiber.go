@@ -14,20 +14,69 @@ type Healthchecker interface {
 	Ready(ctx context.Context) *svchealthcheck.CheckResponse
 }
 
+// HistoryProvider is implemented by the Healthchecker implementations that keep the history of the checks, like the
+// svchealthcheck.Healthcheck.
+type HistoryProvider interface {
+	Histories(ctx context.Context) map[string]svchealthcheck.CheckHistory
+}
+
 // FiberApp abstracts the implementation of the fiber.App.
 type FiberApp interface {
 	Get(path string, handlers ...fiber.Handler) fiber.Router
 }
 
 // FiberInitialize that will set up the endpoints on a given fiber.App.
-func FiberInitialize(healthcheck Healthchecker, app FiberApp) {
-	app.Get(svchealthcheck.HealthPath, fiberEndpoint(healthcheck.Health))
-	app.Get(svchealthcheck.ReadyPath, fiberEndpoint(healthcheck.Ready))
+func FiberInitialize(healthcheck Healthchecker, app FiberApp, opts ...Option) {
+	o := defaultOpts()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	app.Get(svchealthcheck.HealthPath, fiberEndpoint(healthcheck.Health, &o))
+	app.Get(svchealthcheck.ReadyPath, fiberEndpoint(healthcheck.Ready, &o))
+	if history, ok := healthcheck.(HistoryProvider); ok {
+		app.Get(svchealthcheck.HistoryPath, historyEndpoint(history, &o))
+	}
 }
 
-func fiberEndpoint(getResponse func(ctx context.Context) *svchealthcheck.CheckResponse) fiber.Handler {
+func fiberEndpoint(getResponse func(ctx context.Context) *svchealthcheck.CheckResponse, o *options) fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
-		r := getResponse(ctx.Context())
+		if o.authorizer == nil {
+			r := getResponse(ctx.Context())
+			return ctx.Status(r.StatusCode).JSON(r)
+		}
+
+		if o.authorizer(ctx) {
+			r := getResponse(svchealthcheck.ContextWithAuthenticated(ctx.Context()))
+			return ctx.Status(r.StatusCode).JSON(r)
+		}
+		r := getResponse(ctx.Context()).WithDetail(o.anonymousDetail)
 		return ctx.Status(r.StatusCode).JSON(r)
 	}
 }
+
+// historyEndpoint serves the histories of all the checks or, when the check query parameter is set, of a single check.
+func historyEndpoint(history HistoryProvider, o *options) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		reqCtx := context.Context(ctx.Context())
+		authorized := o.authorizer == nil || o.authorizer(ctx)
+		if o.authorizer != nil && authorized {
+			reqCtx = svchealthcheck.ContextWithAuthenticated(reqCtx)
+		}
+		histories := history.Histories(reqCtx)
+		if !authorized {
+			for name, h := range histories {
+				histories[name] = h.WithDetail(o.anonymousDetail)
+			}
+		}
+
+		check := ctx.Query("check")
+		if check == "" {
+			return ctx.JSON(histories)
+		}
+		h, ok := histories[check]
+		if !ok {
+			return ctx.SendStatus(fiber.StatusNotFound)
+		}
+		return ctx.JSON(h)
+	}
+}
@@ -3,7 +3,9 @@
 package hcfiber
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -50,3 +52,124 @@ func TestFiberInitialize(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, readyCheckResponseWriter.StatusCode)
 	assert.Equal(t, "ready", readyCheckResponse.Status)
 }
+
+func TestFiberInitialize_withAuthorizer(t *testing.T) {
+	detailed := &svchealthcheck.CheckResponse{
+		StatusCode: http.StatusServiceUnavailable,
+		Status:     "Service Unavailable",
+		Checks: map[string]svchealthcheck.CheckResponseEntry{
+			"database": {Error: "connection refused"},
+		},
+	}
+
+	serve := func(t *testing.T, app *fiber.App, authorization string) (int, svchealthcheck.CheckResponse) {
+		req := httptest.NewRequest("GET", svchealthcheck.ReadyPath, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		var response svchealthcheck.CheckResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return resp.StatusCode, response
+	}
+
+	t.Run("should return the detailed response to authorized requests", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Ready(gomock.Any()).DoAndReturn(func(ctx context.Context) *svchealthcheck.CheckResponse {
+			assert.True(t, svchealthcheck.IsAuthenticated(ctx))
+			return detailed
+		})
+
+		app := fiber.New()
+		FiberInitialize(mockHC, app, WithAuthorizer(BearerToken("s3cr3t")))
+
+		code, response := serve(t, app, "Bearer s3cr3t")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "connection refused", response.Checks["database"].Error)
+	})
+
+	t.Run("should return only the status to anonymous requests", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Ready(gomock.Any()).DoAndReturn(func(ctx context.Context) *svchealthcheck.CheckResponse {
+			assert.False(t, svchealthcheck.IsAuthenticated(ctx))
+			return detailed
+		})
+
+		app := fiber.New()
+		FiberInitialize(mockHC, app, WithAuthorizer(BasicAuth("admin", "s3cr3t")))
+
+		code, response := serve(t, app, "")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "Service Unavailable", response.Status)
+		assert.Empty(t, response.Checks)
+	})
+}
+
+func TestFiberInitialize_history(t *testing.T) {
+	hc := svchealthcheck.NewHealthcheck(
+		svchealthcheck.WithCheck("database", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})),
+	)
+	hc.Health(context.Background())
+
+	serve := func(t *testing.T, app *fiber.App, target, authorization string) *http.Response {
+		req := httptest.NewRequest("GET", target, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("should return the histories of all checks", func(t *testing.T) {
+		app := fiber.New()
+		FiberInitialize(hc, app)
+
+		resp := serve(t, app, svchealthcheck.HistoryPath, "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var histories map[string]svchealthcheck.CheckHistory
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&histories))
+		require.Contains(t, histories, "database")
+		require.Len(t, histories["database"].Entries, 1)
+		assert.Equal(t, "connection refused", histories["database"].Entries[0].Error)
+		assert.NotNil(t, histories["database"].LastFailure)
+	})
+
+	t.Run("should return the history of a single check", func(t *testing.T) {
+		app := fiber.New()
+		FiberInitialize(hc, app)
+
+		resp := serve(t, app, svchealthcheck.HistoryPath+"?check=database", "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var history svchealthcheck.CheckHistory
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+		assert.Equal(t, float64(0), history.Uptime)
+		assert.Len(t, history.Entries, 1)
+	})
+
+	t.Run("should return not found for unknown checks", func(t *testing.T) {
+		app := fiber.New()
+		FiberInitialize(hc, app)
+
+		resp := serve(t, app, svchealthcheck.HistoryPath+"?check=unknown", "")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("should return only the summary to anonymous requests", func(t *testing.T) {
+		app := fiber.New()
+		FiberInitialize(hc, app, WithAuthorizer(BearerToken("s3cr3t")))
+
+		var histories map[string]svchealthcheck.CheckHistory
+		require.NoError(t, json.NewDecoder(serve(t, app, svchealthcheck.HistoryPath, "").Body).Decode(&histories))
+		assert.Empty(t, histories["database"].Entries)
+		assert.NotNil(t, histories["database"].LastFailure)
+
+		require.NoError(t, json.NewDecoder(serve(t, app, svchealthcheck.HistoryPath, "Bearer s3cr3t").Body).Decode(&histories))
+		assert.Len(t, histories["database"].Entries, 1)
+	})
+}
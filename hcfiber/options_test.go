@@ -0,0 +1,50 @@
+package hcfiber
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func newFiberCtx(t *testing.T, authorization string) *fiber.Ctx {
+	t.Helper()
+
+	app := fiber.New()
+	ctx := app.AcquireCtx(&fasthttp.RequestCtx{})
+	t.Cleanup(func() {
+		app.ReleaseCtx(ctx)
+	})
+	if authorization != "" {
+		ctx.Request().Header.Set(fiber.HeaderAuthorization, authorization)
+	}
+	return ctx
+}
+
+func TestBearerToken(t *testing.T) {
+	assert.False(t, BearerToken("s3cr3t")(newFiberCtx(t, "")))
+	assert.True(t, BearerToken("s3cr3t")(newFiberCtx(t, "Bearer s3cr3t")))
+}
+
+func TestBasicAuth(t *testing.T) {
+	assert.False(t, BasicAuth("admin", "s3cr3t")(newFiberCtx(t, "")))
+	credentials := base64.StdEncoding.EncodeToString([]byte("admin:s3cr3t"))
+	assert.True(t, BasicAuth("admin", "s3cr3t")(newFiberCtx(t, "Basic "+credentials)))
+}
+
+func TestWithAuthorizer(t *testing.T) {
+	opts := defaultOpts()
+	WithAuthorizer(BearerToken("s3cr3t"))(&opts)
+	assert.NotNil(t, opts.authorizer)
+}
+
+func TestWithAnonymousDetail(t *testing.T) {
+	opts := defaultOpts()
+	assert.Equal(t, svchealthcheck.DetailStatusOnly, opts.anonymousDetail)
+	WithAnonymousDetail(svchealthcheck.DetailNoErrors)(&opts)
+	assert.Equal(t, svchealthcheck.DetailNoErrors, opts.anonymousDetail)
+}
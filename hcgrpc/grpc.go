@@ -0,0 +1,178 @@
+package hcgrpc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+const (
+	// ReadyService is the default service name that maps to the overall readiness of the svchealthcheck.Healthcheck.
+	// The empty service name maps to the overall health.
+	ReadyService = "ready"
+)
+
+// Healthchecker abstracts the implementation of the svchealthcheck.Healthcheck.
+type Healthchecker interface {
+	Health(ctx context.Context) *svchealthcheck.CheckResponse
+	Ready(ctx context.Context) *svchealthcheck.CheckResponse
+	Check(ctx context.Context, kind svchealthcheck.CheckKind, name string) (*svchealthcheck.CheckResponse, bool)
+	CheckTag(ctx context.Context, kind svchealthcheck.CheckKind, tag string) (*svchealthcheck.CheckResponse, bool)
+}
+
+type Option func(*options)
+
+type options struct {
+	watchInterval time.Duration
+	readyService  string
+}
+
+func defaultOpts() options {
+	return options{
+		watchInterval: time.Second * 5,
+		readyService:  ReadyService,
+	}
+}
+
+// WithWatchInterval sets how often the checks are evaluated while a client is watching a service.
+func WithWatchInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.watchInterval = interval
+	}
+}
+
+// WithReadyService sets the service name that maps to the overall readiness, instead of ReadyService. The checks and
+// tags with this name can only be reached by changing it, and an empty name leaves the readiness without a service.
+func WithReadyService(name string) Option {
+	return func(o *options) {
+		o.readyService = name
+	}
+}
+
+// Server implements the grpc.health.v1.Health service on top of a Healthchecker.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+	healthcheck   Healthchecker
+	watchInterval time.Duration
+	readyService  string
+}
+
+// NewServer returns a new Server for the given Healthchecker.
+func NewServer(healthcheck Healthchecker, opts ...Option) *Server {
+	o := defaultOpts()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Server{
+		healthcheck:   healthcheck,
+		watchInterval: o.watchInterval,
+		readyService:  o.readyService,
+	}
+}
+
+// GRPCInitialize registers the grpc.health.v1.Health service, backed by the given Healthchecker, on the registrar.
+func GRPCInitialize(healthcheck Healthchecker, registrar grpc.ServiceRegistrar, opts ...Option) {
+	healthpb.RegisterHealthServer(registrar, NewServer(healthcheck, opts...))
+}
+
+// Check implements the healthpb.HealthServer interface.
+func (s *Server) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	r, ok := s.response(ctx, req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	if rateLimited(r) {
+		return nil, status.Error(codes.ResourceExhausted, r.Status)
+	}
+	return &healthpb.HealthCheckResponse{Status: responseStatus(r)}, nil
+}
+
+// Watch implements the healthpb.HealthServer interface. The checks are evaluated every watch interval and a new
+// message is sent only when the serving status changes. Evaluations rejected by the limits of the Healthchecker keep
+// the last status, or report NOT_SERVING when there is none yet.
+func (s *Server) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		st := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		if r, ok := s.response(ctx, req.GetService()); ok {
+			st = responseStatus(r)
+			if rateLimited(r) {
+				st = healthpb.HealthCheckResponse_NOT_SERVING
+				if last >= 0 {
+					st = last
+				}
+			}
+		}
+		if st != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: st}); err != nil {
+				return err
+			}
+			last = st
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// response evaluates the checks of the given service, returning false for unknown services. The empty service maps to
+// the overall health, the ready service to the overall readiness and any other name to the health or ready check with
+// that name or, when there is none, to the health or ready checks with that tag. Named services only evaluate their
+// checks, preferring the health checks.
+func (s *Server) response(ctx context.Context, service string) (*svchealthcheck.CheckResponse, bool) {
+	switch {
+	case service == "":
+		return s.healthcheck.Health(ctx), true
+	case service == s.readyService:
+		return s.healthcheck.Ready(ctx), true
+	}
+
+	// Only the serving status of the checks leaves the server, so the evaluation needs the entries even when
+	// svchealthcheck.WithPublicDetail hides them from the public responses.
+	ctx = svchealthcheck.ContextWithAuthenticated(ctx)
+
+	lookups := []func(ctx context.Context, kind svchealthcheck.CheckKind, name string) (*svchealthcheck.CheckResponse, bool){
+		s.healthcheck.Check,
+		s.healthcheck.CheckTag,
+	}
+	for _, lookup := range lookups {
+		for _, kind := range []svchealthcheck.CheckKind{svchealthcheck.KindHealth, svchealthcheck.KindReady} {
+			if r, ok := lookup(ctx, kind, service); ok {
+				return r, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// rateLimited returns true if the response rejects an evaluation that exceeded the limits of the Healthchecker.
+// Responses served from the last evaluation are marked as cached.
+func rateLimited(r *svchealthcheck.CheckResponse) bool {
+	return r.StatusCode == http.StatusTooManyRequests && !r.Cached
+}
+
+func responseStatus(r *svchealthcheck.CheckResponse) healthpb.HealthCheckResponse_ServingStatus {
+	return outcomeStatus(r.Outcome)
+}
+
+// outcomeStatus maps an outcome into a serving status. Warnings and skipped checks are still serving.
+func outcomeStatus(outcome svchealthcheck.Outcome) healthpb.HealthCheckResponse_ServingStatus {
+	if outcome.Failed() {
+		return healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	return healthpb.HealthCheckResponse_SERVING
+}
@@ -0,0 +1,330 @@
+//go:generate go run github.com/golang/mock/mockgen -package hcgrpc -destination=mocks_mock_test.go . Healthchecker
+
+package hcgrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func startServer(t *testing.T, healthcheck Healthchecker, opts ...Option) healthpb.HealthClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	GRPCInitialize(healthcheck, srv, opts...)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestServer_Check(t *testing.T) {
+	ctx := context.Background()
+
+	healthy := &svchealthcheck.CheckResponse{
+		StatusCode: http.StatusOK,
+		Outcome:    svchealthcheck.OutcomePass,
+		Checks: map[string]svchealthcheck.CheckResponseEntry{
+			"database": {Outcome: svchealthcheck.OutcomePass},
+		},
+	}
+	notReady := &svchealthcheck.CheckResponse{
+		StatusCode: http.StatusServiceUnavailable,
+		Outcome:    svchealthcheck.OutcomeFail,
+		Checks: map[string]svchealthcheck.CheckResponseEntry{
+			"cache": {Outcome: svchealthcheck.OutcomeFail, Error: "connection refused"},
+		},
+	}
+
+	t.Run("should map the empty service to the overall health", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Health(gomock.Any()).Return(healthy)
+
+		resp, err := startServer(t, mockHC).Check(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	})
+
+	t.Run("should map the ready service to the overall readiness", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Ready(gomock.Any()).Return(notReady)
+
+		resp, err := startServer(t, mockHC).Check(ctx, &healthpb.HealthCheckRequest{Service: ReadyService})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+	})
+
+	t.Run("should map a named service to a health check", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Check(gomock.Any(), svchealthcheck.KindHealth, "database").Return(healthy, true)
+
+		resp, err := startServer(t, mockHC).Check(ctx, &healthpb.HealthCheckRequest{Service: "database"})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	})
+
+	t.Run("should map a named service to a ready check", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Check(gomock.Any(), svchealthcheck.KindHealth, "cache").Return(nil, false)
+		mockHC.EXPECT().Check(gomock.Any(), svchealthcheck.KindReady, "cache").Return(notReady, true)
+
+		resp, err := startServer(t, mockHC).Check(ctx, &healthpb.HealthCheckRequest{Service: "cache"})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+	})
+
+	t.Run("should map a named service to a tag", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Check(gomock.Any(), gomock.Any(), "storage").Return(nil, false).Times(2)
+		mockHC.EXPECT().CheckTag(gomock.Any(), svchealthcheck.KindHealth, "storage").Return(healthy, true)
+
+		resp, err := startServer(t, mockHC).Check(ctx, &healthpb.HealthCheckRequest{Service: "storage"})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	})
+
+	t.Run("should map the configured ready service to the overall readiness", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Ready(gomock.Any()).Return(notReady)
+		mockHC.EXPECT().Check(gomock.Any(), svchealthcheck.KindHealth, ReadyService).Return(healthy, true)
+
+		client := startServer(t, mockHC, WithReadyService("readiness"))
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "readiness"})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+		resp, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: ReadyService})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status, "the check named ready should be reachable")
+	})
+
+	t.Run("should report warnings as serving", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Health(gomock.Any()).Return(&svchealthcheck.CheckResponse{
+			StatusCode: http.StatusOK,
+			Outcome:    svchealthcheck.OutcomeWarn,
+		})
+
+		resp, err := startServer(t, mockHC).Check(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	})
+
+	t.Run("should return resource exhausted when the evaluation is rate limited", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Check(gomock.Any(), svchealthcheck.KindHealth, "database").Return(&svchealthcheck.CheckResponse{
+			StatusCode: http.StatusTooManyRequests,
+			Status:     "Too Many Requests",
+			Outcome:    svchealthcheck.OutcomeUnknown,
+		}, true)
+
+		_, err := startServer(t, mockHC).Check(ctx, &healthpb.HealthCheckRequest{Service: "database"})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("should return not found for unknown services", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mockHC.EXPECT().Check(gomock.Any(), gomock.Any(), "unknown").Return(nil, false).Times(2)
+		mockHC.EXPECT().CheckTag(gomock.Any(), gomock.Any(), "unknown").Return(nil, false).Times(2)
+
+		_, err := startServer(t, mockHC).Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestServer_Check_evaluatesOnlyTheServiceChecks(t *testing.T) {
+	var healthRuns, otherRuns, readyRuns atomic.Int32
+	hc := svchealthcheck.NewHealthcheck(
+		svchealthcheck.WithCheck("database", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			healthRuns.Add(1)
+			return nil
+		})),
+		svchealthcheck.WithCheck("other", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			otherRuns.Add(1)
+			return nil
+		})),
+		svchealthcheck.WithReadyCheck("cache", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			readyRuns.Add(1)
+			return nil
+		})),
+	)
+	client := startServer(t, hc)
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "database"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), healthRuns.Load())
+	assert.Equal(t, int32(0), readyRuns.Load())
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "cache"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), healthRuns.Load())
+	assert.Equal(t, int32(1), readyRuns.Load())
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, int32(1), healthRuns.Load())
+	assert.Equal(t, int32(1), readyRuns.Load())
+	assert.Equal(t, int32(0), otherRuns.Load())
+}
+
+func TestServer_Check_publicDetail(t *testing.T) {
+	hc := svchealthcheck.NewHealthcheck(
+		svchealthcheck.WithPublicDetail(svchealthcheck.DetailStatusOnly),
+		svchealthcheck.WithCheck("database", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})),
+	)
+	client := startServer(t, hc)
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "database"})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+	resp, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
+
+func TestServer_Watch(t *testing.T) {
+	var failing atomic.Bool
+	hc := svchealthcheck.NewHealthcheck(
+		svchealthcheck.WithCheck("database", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			if failing.Load() {
+				return errors.New("connection refused")
+			}
+			return nil
+		})),
+	)
+
+	client := startServer(t, hc, WithWatchInterval(time.Millisecond*10))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "database"})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	failing.Store(true)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+	failing.Store(false)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
+
+func TestServer_Watch_rateLimited(t *testing.T) {
+	var calls atomic.Int32
+	hc := svchealthcheck.NewHealthcheck(
+		svchealthcheck.WithLimitPolicy(svchealthcheck.LimitReject),
+		svchealthcheck.WithRateLimit(0.001, 1),
+		svchealthcheck.WithCheck("database", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		})),
+	)
+	client := startServer(t, hc, WithWatchInterval(time.Millisecond*10))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "database"})
+	require.NoError(t, err)
+	_, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "database"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "a rate limited check should not be reported as unknown")
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "database"})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestServer_Watch_rateLimitedKeepsTheLastStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockHC := NewMockHealthchecker(ctrl)
+	gomock.InOrder(
+		mockHC.EXPECT().Health(gomock.Any()).Return(&svchealthcheck.CheckResponse{
+			StatusCode: http.StatusOK,
+			Outcome:    svchealthcheck.OutcomePass,
+		}),
+		mockHC.EXPECT().Health(gomock.Any()).Return(&svchealthcheck.CheckResponse{
+			StatusCode: http.StatusTooManyRequests,
+			Outcome:    svchealthcheck.OutcomeUnknown,
+		}).AnyTimes(),
+	)
+	client := startServer(t, mockHC, WithWatchInterval(time.Millisecond*10))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err), "no other status should be sent")
+}
+
+func TestServer_Watch_unknownService(t *testing.T) {
+	client := startServer(t, svchealthcheck.NewHealthcheck())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, resp.Status)
+}
@@ -14,21 +14,80 @@ type Healthchecker interface {
 	Ready(ctx context.Context) *svchealthcheck.CheckResponse
 }
 
+// HistoryProvider is implemented by the Healthchecker implementations that keep the history of the checks, like the
+// svchealthcheck.Healthcheck.
+type HistoryProvider interface {
+	Histories(ctx context.Context) map[string]svchealthcheck.CheckHistory
+}
+
 // ServeMux abstracts the implementation of the http.ServeMux.
 type ServeMux interface {
 	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
 }
 
 // HttpInitialize that will set up the endpoints on a given http.ServeMux.
-func HttpInitialize(healthcheck Healthchecker, mux ServeMux) {
-	mux.HandleFunc(svchealthcheck.HealthPath, httpEndpoint(healthcheck.Health))
-	mux.HandleFunc(svchealthcheck.ReadyPath, httpEndpoint(healthcheck.Ready))
+func HttpInitialize(healthcheck Healthchecker, mux ServeMux, opts ...Option) {
+	o := defaultOpts()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	mux.HandleFunc(svchealthcheck.HealthPath, httpEndpoint(healthcheck.Health, &o))
+	mux.HandleFunc(svchealthcheck.ReadyPath, httpEndpoint(healthcheck.Ready, &o))
+	if history, ok := healthcheck.(HistoryProvider); ok {
+		mux.HandleFunc(svchealthcheck.HistoryPath, historyEndpoint(history, &o))
+	}
+}
+
+func httpEndpoint(getResponse func(ctx context.Context) *svchealthcheck.CheckResponse, o *options) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if o.authorizer == nil {
+			writeResponse(writer, getResponse(request.Context()))
+			return
+		}
+
+		if o.authorizer(request) {
+			writeResponse(writer, getResponse(svchealthcheck.ContextWithAuthenticated(request.Context())))
+			return
+		}
+		writeResponse(writer, getResponse(request.Context()).WithDetail(o.anonymousDetail))
+	}
 }
 
-func httpEndpoint(getResponse func(ctx context.Context) *svchealthcheck.CheckResponse) http.HandlerFunc {
+// historyEndpoint serves the histories of all the checks or, when the check query parameter is set, of a single check.
+func historyEndpoint(history HistoryProvider, o *options) http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
-		r := getResponse(request.Context())
-		writer.WriteHeader(r.StatusCode)
-		_ = json.NewEncoder(writer).Encode(r)
+		ctx := request.Context()
+		authorized := o.authorizer == nil || o.authorizer(request)
+		if o.authorizer != nil && authorized {
+			ctx = svchealthcheck.ContextWithAuthenticated(ctx)
+		}
+		histories := history.Histories(ctx)
+		if !authorized {
+			for name, h := range histories {
+				histories[name] = h.WithDetail(o.anonymousDetail)
+			}
+		}
+
+		check := request.URL.Query().Get("check")
+		if check == "" {
+			writeJSON(writer, http.StatusOK, histories)
+			return
+		}
+		h, ok := histories[check]
+		if !ok {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(writer, http.StatusOK, h)
 	}
 }
+
+func writeJSON(writer http.ResponseWriter, statusCode int, v interface{}) {
+	writer.WriteHeader(statusCode)
+	_ = json.NewEncoder(writer).Encode(v)
+}
+
+func writeResponse(writer http.ResponseWriter, r *svchealthcheck.CheckResponse) {
+	writer.WriteHeader(r.StatusCode)
+	_ = json.NewEncoder(writer).Encode(r)
+}
@@ -5,6 +5,7 @@ package hchttp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -67,3 +68,133 @@ func TestHttpInitialize(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, readyCheckResponseWriter.Code)
 	assert.Equal(t, "ready", readyCheckResponse.Status)
 }
+
+func TestHttpInitialize_withAuthorizer(t *testing.T) {
+	detailed := &svchealthcheck.CheckResponse{
+		StatusCode: http.StatusServiceUnavailable,
+		Status:     "Service Unavailable",
+		Checks: map[string]svchealthcheck.CheckResponseEntry{
+			"database": {Error: "connection refused"},
+		},
+	}
+
+	setup := func(t *testing.T, opts ...Option) (*MockHealthchecker, http.Handler) {
+		ctrl := gomock.NewController(t)
+		mockHC := NewMockHealthchecker(ctrl)
+		mux := http.NewServeMux()
+		HttpInitialize(mockHC, mux, opts...)
+		return mockHC, mux
+	}
+
+	serve := func(t *testing.T, handler http.Handler, authorization string) (int, svchealthcheck.CheckResponse) {
+		req := httptest.NewRequest("GET", svchealthcheck.HealthPath, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		var response svchealthcheck.CheckResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		return w.Code, response
+	}
+
+	t.Run("should return the detailed response to authorized requests", func(t *testing.T) {
+		mockHC, handler := setup(t, WithAuthorizer(BearerToken("s3cr3t")))
+		mockHC.EXPECT().Health(gomock.Any()).DoAndReturn(func(ctx context.Context) *svchealthcheck.CheckResponse {
+			assert.True(t, svchealthcheck.IsAuthenticated(ctx))
+			return detailed
+		})
+
+		code, response := serve(t, handler, "Bearer s3cr3t")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "connection refused", response.Checks["database"].Error)
+	})
+
+	t.Run("should return only the status to anonymous requests", func(t *testing.T) {
+		mockHC, handler := setup(t, WithAuthorizer(BearerToken("s3cr3t")))
+		mockHC.EXPECT().Health(gomock.Any()).DoAndReturn(func(ctx context.Context) *svchealthcheck.CheckResponse {
+			assert.False(t, svchealthcheck.IsAuthenticated(ctx))
+			return detailed
+		})
+
+		code, response := serve(t, handler, "Bearer wrong")
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "Service Unavailable", response.Status)
+		assert.Empty(t, response.Checks)
+	})
+
+	t.Run("should use the anonymous detail level", func(t *testing.T) {
+		mockHC, handler := setup(t, WithAuthorizer(BasicAuth("admin", "s3cr3t")), WithAnonymousDetail(svchealthcheck.DetailNoErrors))
+		mockHC.EXPECT().Health(gomock.Any()).Return(detailed)
+
+		_, response := serve(t, handler, "")
+		require.Contains(t, response.Checks, "database")
+		assert.Empty(t, response.Checks["database"].Error)
+	})
+}
+
+func TestHttpInitialize_history(t *testing.T) {
+	hc := svchealthcheck.NewHealthcheck(
+		svchealthcheck.WithCheck("database", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})),
+	)
+	hc.Health(context.Background())
+
+	serve := func(t *testing.T, handler http.Handler, target, authorization string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("should return the histories of all checks", func(t *testing.T) {
+		mux := http.NewServeMux()
+		HttpInitialize(hc, mux)
+
+		w := serve(t, mux, svchealthcheck.HistoryPath, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var histories map[string]svchealthcheck.CheckHistory
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&histories))
+		require.Contains(t, histories, "database")
+		require.Len(t, histories["database"].Entries, 1)
+		assert.Equal(t, "connection refused", histories["database"].Entries[0].Error)
+		assert.NotNil(t, histories["database"].LastFailure)
+	})
+
+	t.Run("should return the history of a single check", func(t *testing.T) {
+		mux := http.NewServeMux()
+		HttpInitialize(hc, mux)
+
+		w := serve(t, mux, svchealthcheck.HistoryPath+"?check=database", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var history svchealthcheck.CheckHistory
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&history))
+		assert.Equal(t, float64(0), history.Uptime)
+		assert.Len(t, history.Entries, 1)
+	})
+
+	t.Run("should return not found for unknown checks", func(t *testing.T) {
+		mux := http.NewServeMux()
+		HttpInitialize(hc, mux)
+
+		w := serve(t, mux, svchealthcheck.HistoryPath+"?check=unknown", "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("should return only the summary to anonymous requests", func(t *testing.T) {
+		mux := http.NewServeMux()
+		HttpInitialize(hc, mux, WithAuthorizer(BearerToken("s3cr3t")))
+
+		var histories map[string]svchealthcheck.CheckHistory
+		require.NoError(t, json.NewDecoder(serve(t, mux, svchealthcheck.HistoryPath, "").Body).Decode(&histories))
+		assert.Empty(t, histories["database"].Entries)
+		assert.NotNil(t, histories["database"].LastFailure)
+
+		require.NoError(t, json.NewDecoder(serve(t, mux, svchealthcheck.HistoryPath, "Bearer s3cr3t").Body).Decode(&histories))
+		assert.Len(t, histories["database"].Entries, 1)
+	})
+}
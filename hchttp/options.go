@@ -0,0 +1,54 @@
+package hchttp
+
+import (
+	"net/http"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+// Authorizer returns true if the request is allowed to see the detailed health output.
+type Authorizer func(r *http.Request) bool
+
+// BearerToken returns an Authorizer that accepts requests with the given bearer token.
+func BearerToken(token string) Authorizer {
+	return func(r *http.Request) bool {
+		return svchealthcheck.CheckBearerToken(r.Header.Get("Authorization"), token)
+	}
+}
+
+// BasicAuth returns an Authorizer that accepts requests with the given basic auth credentials.
+func BasicAuth(username, password string) Authorizer {
+	return func(r *http.Request) bool {
+		return svchealthcheck.CheckBasicAuth(r.Header.Get("Authorization"), username, password)
+	}
+}
+
+type Option func(*options)
+
+type options struct {
+	authorizer      Authorizer
+	anonymousDetail svchealthcheck.DetailLevel
+}
+
+func defaultOpts() options {
+	return options{
+		anonymousDetail: svchealthcheck.DetailStatusOnly,
+	}
+}
+
+// WithAuthorizer sets the Authorizer that decides which requests see the detailed health output. Authorized requests
+// are marked with svchealthcheck.ContextWithAuthenticated, the others receive the detail level set by
+// WithAnonymousDetail.
+func WithAuthorizer(authorizer Authorizer) Option {
+	return func(o *options) {
+		o.authorizer = authorizer
+	}
+}
+
+// WithAnonymousDetail sets the detail level of the responses to unauthorized requests. It defaults to
+// svchealthcheck.DetailStatusOnly and only has effect when WithAuthorizer is used.
+func WithAnonymousDetail(level svchealthcheck.DetailLevel) Option {
+	return func(o *options) {
+		o.anonymousDetail = level
+	}
+}
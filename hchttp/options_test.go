@@ -0,0 +1,39 @@
+package hchttp
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func TestBearerToken(t *testing.T) {
+	req := httptest.NewRequest("GET", svchealthcheck.HealthPath, nil)
+	assert.False(t, BearerToken("s3cr3t")(req))
+
+	req.Header.Set("Authorization", "Bearer s3cr3t")
+	assert.True(t, BearerToken("s3cr3t")(req))
+}
+
+func TestBasicAuth(t *testing.T) {
+	req := httptest.NewRequest("GET", svchealthcheck.HealthPath, nil)
+	assert.False(t, BasicAuth("admin", "s3cr3t")(req))
+
+	req.SetBasicAuth("admin", "s3cr3t")
+	assert.True(t, BasicAuth("admin", "s3cr3t")(req))
+}
+
+func TestWithAuthorizer(t *testing.T) {
+	opts := defaultOpts()
+	WithAuthorizer(BearerToken("s3cr3t"))(&opts)
+	assert.NotNil(t, opts.authorizer)
+}
+
+func TestWithAnonymousDetail(t *testing.T) {
+	opts := defaultOpts()
+	assert.Equal(t, svchealthcheck.DetailStatusOnly, opts.anonymousDetail)
+	WithAnonymousDetail(svchealthcheck.DetailNoErrors)(&opts)
+	assert.Equal(t, svchealthcheck.DetailNoErrors, opts.anonymousDetail)
+}
@@ -0,0 +1,117 @@
+// Package hcotel traces the evaluations of the svchealthcheck.Healthcheck checks with OpenTelemetry.
+package hcotel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+const (
+	instrumentationName = "github.com/jamillosantos/services-healthcheck/hcotel"
+
+	AttrKind       = attribute.Key("healthcheck.kind")
+	AttrCheckName  = attribute.Key("healthcheck.check.name")
+	AttrOutcome    = attribute.Key("healthcheck.outcome")
+	AttrPanic      = attribute.Key("healthcheck.check.panic")
+	AttrStatusCode = attribute.Key("healthcheck.status_code")
+
+	// attrExceptionStacktrace is the semantic convention attribute of the stack trace of exception events.
+	attrExceptionStacktrace = attribute.Key("exception.stacktrace")
+)
+
+type Option func(*options)
+
+type options struct {
+	tracerProvider trace.TracerProvider
+}
+
+// WithTracerProvider sets the TracerProvider used to create the spans. It defaults to the global TracerProvider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracerProvider = tp
+	}
+}
+
+// Observer implements svchealthcheck.Observer creating a span for each evaluation of the checks and a child span for
+// each checker. The span of the checker is in the context given to it, so the spans created by the checker are nested.
+type Observer struct {
+	tracer trace.Tracer
+}
+
+// NewObserver returns a new Observer. Use it with svchealthcheck.WithObserver.
+func NewObserver(opts ...Option) *Observer {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.tracerProvider == nil {
+		o.tracerProvider = otel.GetTracerProvider()
+	}
+	return &Observer{
+		tracer: o.tracerProvider.Tracer(instrumentationName),
+	}
+}
+
+// EvaluationStarted implements the svchealthcheck.Observer interface.
+func (o *Observer) EvaluationStarted(ctx context.Context, kind svchealthcheck.CheckKind) context.Context {
+	ctx, _ = o.tracer.Start(ctx, "healthcheck."+string(kind),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(AttrKind.String(string(kind))),
+	)
+	return ctx
+}
+
+// EvaluationFinished implements the svchealthcheck.Observer interface.
+func (o *Observer) EvaluationFinished(ctx context.Context, _ svchealthcheck.CheckKind, response *svchealthcheck.CheckResponse) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		AttrOutcome.String(string(response.Outcome)),
+		AttrStatusCode.Int(response.StatusCode),
+	)
+	if response.Outcome.Failed() {
+		span.SetStatus(codes.Error, response.Status)
+	}
+	span.End()
+}
+
+// CheckStarted implements the svchealthcheck.Observer interface.
+func (o *Observer) CheckStarted(ctx context.Context, kind svchealthcheck.CheckKind, name string) context.Context {
+	ctx, _ = o.tracer.Start(ctx, "healthcheck.check "+name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			AttrKind.String(string(kind)),
+			AttrCheckName.String(name),
+		),
+	)
+	return ctx
+}
+
+// CheckFinished implements the svchealthcheck.Observer interface.
+func (o *Observer) CheckFinished(ctx context.Context, _ svchealthcheck.CheckKind, _ string, result svchealthcheck.CheckResult, _ time.Duration) {
+	span := trace.SpanFromContext(ctx)
+	outcome := result.EffectiveOutcome()
+	span.SetAttributes(
+		AttrOutcome.String(string(outcome)),
+		AttrPanic.Bool(svchealthcheck.IsPanic(result.Err)),
+	)
+	var description string
+	if result.Err != nil {
+		var opts []trace.EventOption
+		if stack := svchealthcheck.PanicStack(result.Err); stack != nil {
+			opts = append(opts, trace.WithAttributes(attrExceptionStacktrace.String(string(stack))))
+		}
+		span.RecordError(result.Err, opts...)
+		description = result.Err.Error()
+	}
+	if outcome.Failed() {
+		span.SetStatus(codes.Error, description)
+	}
+	span.End()
+}
@@ -0,0 +1,112 @@
+package hcotel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "span %q not found", name)
+	return tracetest.SpanStub{}
+}
+
+func attributeValue(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestObserver(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := tp.Tracer("test")
+
+	hc := svchealthcheck.NewHealthcheck(
+		svchealthcheck.WithObserver(NewObserver(WithTracerProvider(tp))),
+		svchealthcheck.WithReadyCheck("database", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			_, span := tracer.Start(ctx, "db.ping")
+			span.End()
+			return nil
+		})),
+		svchealthcheck.WithReadyCheck("cache", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})),
+		svchealthcheck.WithReadyCheck("queue", svchealthcheck.CheckerFunc(func(ctx context.Context) error {
+			panic("boom")
+		})),
+	)
+
+	ctx, parent := tracer.Start(context.Background(), "GET /readyz")
+	hc.Ready(ctx)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 6)
+
+	request := findSpan(t, spans, "GET /readyz")
+	evaluation := findSpan(t, spans, "healthcheck.ready")
+	assert.Equal(t, request.SpanContext.SpanID(), evaluation.Parent.SpanID())
+	assert.Equal(t, "ready", attributeValue(evaluation, AttrKind).AsString())
+	assert.Equal(t, "fail", attributeValue(evaluation, AttrOutcome).AsString())
+	assert.Equal(t, int64(500), attributeValue(evaluation, AttrStatusCode).AsInt64())
+	assert.Equal(t, codes.Error, evaluation.Status.Code)
+
+	database := findSpan(t, spans, "healthcheck.check database")
+	assert.Equal(t, evaluation.SpanContext.SpanID(), database.Parent.SpanID())
+	assert.Equal(t, "database", attributeValue(database, AttrCheckName).AsString())
+	assert.Equal(t, "pass", attributeValue(database, AttrOutcome).AsString())
+	assert.False(t, attributeValue(database, AttrPanic).AsBool())
+	assert.Equal(t, codes.Unset, database.Status.Code)
+
+	ping := findSpan(t, spans, "db.ping")
+	assert.Equal(t, database.SpanContext.SpanID(), ping.Parent.SpanID(), "the checker spans should be nested")
+
+	cache := findSpan(t, spans, "healthcheck.check cache")
+	assert.Equal(t, "fail", attributeValue(cache, AttrOutcome).AsString())
+	assert.Equal(t, codes.Error, cache.Status.Code)
+	assert.Equal(t, "connection refused", cache.Status.Description)
+	require.Len(t, cache.Events, 1)
+	assert.Equal(t, "exception", cache.Events[0].Name)
+
+	queue := findSpan(t, spans, "healthcheck.check queue")
+	assert.True(t, attributeValue(queue, AttrPanic).AsBool())
+	assert.Equal(t, codes.Error, queue.Status.Code)
+	require.Len(t, queue.Events, 1)
+	var stack string
+	for _, attr := range queue.Events[0].Attributes {
+		if attr.Key == attrExceptionStacktrace {
+			stack = attr.Value.AsString()
+		}
+	}
+	assert.Contains(t, stack, "goroutine", "the panic stack should be recorded")
+}
+
+func TestNewObserver(t *testing.T) {
+	o := NewObserver()
+	assert.NotNil(t, o.tracer)
+
+	ctx := o.CheckStarted(context.Background(), svchealthcheck.KindHealth, "check")
+	assert.True(t, trace.SpanFromContext(ctx).SpanContext().Equal(trace.SpanContext{}), "the global noop provider should be used")
+	o.CheckFinished(ctx, svchealthcheck.KindHealth, "check", svchealthcheck.CheckResult{Outcome: svchealthcheck.OutcomeFail}, 0)
+}
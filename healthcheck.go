@@ -3,11 +3,12 @@ package svchealthcheck
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
-	"strings"
+	"os"
 	"sync"
 	"time"
+
+	srvfiber "github.com/jamillosantos/server-fiber"
 )
 
 var (
@@ -15,7 +16,23 @@ var (
 )
 
 type Healthcheck struct {
+	bindAddress    string
+	initializer    srvfiber.Initializer
+	socketMode     os.FileMode
+	server         server
 	checkerTimeout time.Duration
+	statusPolicy   StatusPolicy
+	redactors      []Redactor
+	publicDetail   DetailLevel
+	detailLogger   DetailLogger
+	healthLimiter  *limiter
+	readyLimiter   *limiter
+	maxParallelism int
+	stuck          stuckTracker
+	observers      observers
+	history        *history
+	readyHealth    bool
+	tags           map[string][]string
 	hcLock         sync.RWMutex
 	healthCheckers map[string]Checker
 	rdLock         sync.RWMutex
@@ -27,8 +44,31 @@ func NewHealthcheck(opts ...Option) *Healthcheck {
 	for _, opt := range opts {
 		opt(&o)
 	}
+	if o.statusPolicy == nil {
+		o.statusPolicy = &OutcomeStatusPolicy{
+			Codes:     o.statusCodes,
+			PanicCode: o.panicStatusCode,
+		}
+	}
+	if o.logger != nil {
+		o.observers = append(o.observers, newLogObserver(o.logger, o.slowThreshold, o.logInterval))
+	}
 	r := &Healthcheck{
+		bindAddress:    o.bindAddress,
+		initializer:    o.initializer,
+		socketMode:     o.socketMode,
 		checkerTimeout: o.timeout,
+		statusPolicy:   o.statusPolicy,
+		redactors:      o.redactors,
+		publicDetail:   o.publicDetail,
+		detailLogger:   o.detailLogger,
+		healthLimiter:  newLimiter(&o),
+		readyLimiter:   newLimiter(&o),
+		maxParallelism: o.maxParallelism,
+		observers:      o.observers,
+		history:        newHistory(o.historySize),
+		readyHealth:    o.readyWithHealth,
+		tags:           o.tags,
 		healthCheckers: o.healthCheckers,
 		readyCheckers:  o.readyCheckers,
 	}
@@ -47,100 +87,286 @@ func (s *Healthcheck) AddReadyCheck(name string, check Checker) {
 	s.rdLock.Unlock()
 }
 
+// Check evaluates only the check with the given name of the set of the given kind, as Health or Ready would. It
+// returns false when the set has no check with that name.
+func (s *Healthcheck) Check(ctx context.Context, kind CheckKind, name string) (*CheckResponse, bool) {
+	return s.evaluateMatching(ctx, kind, func(check string) bool {
+		return check == name
+	})
+}
+
+// CheckTag evaluates only the checks of the set of the given kind with the given tag, set by WithTags. It returns false
+// when no check of the set has the tag.
+func (s *Healthcheck) CheckTag(ctx context.Context, kind CheckKind, tag string) (*CheckResponse, bool) {
+	return s.evaluateMatching(ctx, kind, func(check string) bool {
+		for _, t := range s.tags[check] {
+			if t == tag {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// evaluateMatching evaluates the checks of the set of the given kind whose names match. The evaluation counts towards
+// the limits of the kind but, as its response is partial, it is not kept to be served to the requests that exceed
+// them.
+func (s *Healthcheck) evaluateMatching(ctx context.Context, kind CheckKind, match func(name string) bool) (*CheckResponse, bool) {
+	var (
+		lock     *sync.RWMutex
+		checkers map[string]Checker
+		l        *limiter
+	)
+	switch kind {
+	case KindHealth:
+		lock, checkers, l = &s.hcLock, s.healthCheckers, s.healthLimiter
+	case KindReady:
+		lock, checkers, l = &s.rdLock, s.readyCheckers, s.readyLimiter
+	default:
+		return nil, false
+	}
+
+	lock.RLock()
+	defer lock.RUnlock()
+
+	checks := make(map[string]Checker)
+	for name, check := range checkers {
+		if match(name) {
+			checks[name] = check
+		}
+	}
+	if len(checks) == 0 {
+		return nil, false
+	}
+
+	if l != nil {
+		release, ok := l.acquire()
+		if !ok {
+			return rateLimitedResponse(), true
+		}
+		defer release()
+	}
+	return s.publicResponse(ctx, s.generateResponse(ctx, kind, checks)), true
+}
+
 func (s *Healthcheck) Health(ctx context.Context) *CheckResponse {
-	s.hcLock.RLock()
-	r := s.generateResponse(ctx, s.healthCheckers)
-	s.hcLock.RUnlock()
-	return r
+	r := s.evaluate(ctx, s.healthLimiter, func(ctx context.Context) *CheckResponse {
+		s.hcLock.RLock()
+		defer s.hcLock.RUnlock()
+		return s.generateResponse(ctx, KindHealth, s.healthCheckers)
+	})
+	return s.publicResponse(ctx, r)
 }
 
 func (s *Healthcheck) Ready(ctx context.Context) *CheckResponse {
+	r := s.evaluate(ctx, s.readyLimiter, func(ctx context.Context) *CheckResponse {
+		if s.readyHealth {
+			return s.generateReadyWithHealth(ctx)
+		}
+		s.rdLock.RLock()
+		defer s.rdLock.RUnlock()
+		return s.generateResponse(ctx, KindReady, s.readyCheckers)
+	})
+	return s.publicResponse(ctx, r)
+}
+
+// generateReadyWithHealth evaluates the ready and the health checks together, as set by WithReadyIncludesHealth. A
+// name registered in both sets runs once, using the ready checker, and each entry lists the sets it belongs to.
+func (s *Healthcheck) generateReadyWithHealth(ctx context.Context) *CheckResponse {
+	s.hcLock.RLock()
+	defer s.hcLock.RUnlock()
 	s.rdLock.RLock()
-	r := s.generateResponse(ctx, s.readyCheckers)
-	s.rdLock.RUnlock()
+	defer s.rdLock.RUnlock()
+
+	checks := make(map[string]Checker, len(s.healthCheckers)+len(s.readyCheckers))
+	kinds := make(map[string][]CheckKind, len(checks))
+	for name, check := range s.healthCheckers {
+		checks[name] = check
+		kinds[name] = []CheckKind{KindHealth}
+	}
+	for name, check := range s.readyCheckers {
+		// A name registered in both sets runs once, with the ready checker.
+		checks[name] = check
+		kinds[name] = append(kinds[name], KindReady)
+	}
+
+	r := s.generateResponse(ctx, KindReady, checks)
+	for name, entry := range r.Checks {
+		entry.Kinds = kinds[name]
+		r.Checks[name] = entry
+	}
 	return r
 }
 
-func (s *Healthcheck) generateResponse(ctx context.Context, checks map[string]Checker) *CheckResponse {
-	if s.checkerTimeout > 0 {
-		ctx2, cancel := context.WithTimeout(ctx, s.checkerTimeout)
-		defer cancel()
-		ctx = ctx2
+// evaluate runs generate, unless the request exceeds the limits of l. The generated response is kept by l to be served
+// to the requests that exceed the limits.
+func (s *Healthcheck) evaluate(ctx context.Context, l *limiter, generate func(ctx context.Context) *CheckResponse) *CheckResponse {
+	if l == nil {
+		return generate(ctx)
 	}
 
+	release, ok := l.acquire()
+	if !ok {
+		return l.limitedResponse(time.Now())
+	}
+	defer release()
+
+	r := generate(ctx)
+	l.store(r, time.Now())
+	return r
+}
+
+// AbandonedChecks returns the number of check runs that timed out and are still running, because the checker ignored
+// the cancellation of the context.
+func (s *Healthcheck) AbandonedChecks() int {
+	return s.stuck.count()
+}
+
+func (s *Healthcheck) generateResponse(ctx context.Context, kind CheckKind, checks map[string]Checker) *CheckResponse {
+	ctx = s.observers.evaluationStarted(ctx, kind)
+
 	jsonResponse := &CheckResponse{
-		StatusCode: http.StatusOK,
-		Checks:     make(map[string]CheckResponseEntry, len(checks)),
+		Checks: make(map[string]CheckResponseEntry, len(checks)),
+	}
+
+	type job struct {
+		key   string
+		check Checker
+	}
+	jobs := make(chan job, len(checks))
+	for key, check := range checks {
+		jobs <- job{key, check}
+	}
+	close(jobs)
+
+	workers := len(checks)
+	if s.maxParallelism > 0 && s.maxParallelism < workers {
+		workers = s.maxParallelism
 	}
 
 	var (
 		wg      sync.WaitGroup
 		checksM sync.Mutex
 	)
-	wg.Add(len(checks))
-	for key, check := range checks {
-		go func(key string, check Checker) {
+	aggregate := Aggregate{
+		Outcome: OutcomePass,
+		Counts:  make(map[Outcome]int),
+	}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
 			defer wg.Done()
-			st := time.Now()
-			errch := make(chan error, 1)
-
-			// Start another goroutine to be able to track timeouts.
-			go func() {
-				defer func() {
-					r := recover()
-					if r == nil {
-						return
-					}
-					handlerRecover(r, errch)
-				}()
-
-				errch <- check.Check(ctx)
-			}()
-
-			var err error
-			select {
-			case <-ctx.Done(): // timeout
-				err = ctx.Err()
-			case e := <-errch:
-				err = e
-			}
+			for j := range jobs {
+				checkCtx := s.observers.checkStarted(ctx, kind, j.key)
+				st := time.Now()
+				result := s.runWithTimeout(checkCtx, checkKey{kind: kind, name: j.key}, j.check)
+				duration := time.Since(st)
+				s.observers.checkFinished(checkCtx, kind, j.key, result, duration)
 
-			checksM.Lock()
-			if err != nil { // If check fails, return service unavailable.
-				jsonResponse.StatusCode = errorToStatus(jsonResponse.StatusCode, err)
-			}
-			jsonResponse.Checks[key] = CheckResponseEntry{
-				Duration: time.Since(st).String(),
-				Error:    errorMessage(err),
+				entry := newCheckResponseEntry(result)
+				entry.Duration = duration.String()
+				s.history.record(j.key, HistoryEntry{
+					Time:     st,
+					Kind:     kind,
+					Outcome:  entry.Outcome,
+					Duration: entry.Duration,
+					Error:    redact(entry.Error, s.redactors),
+				})
+
+				checksM.Lock()
+				aggregate.Outcome = WorstOutcome(aggregate.Outcome, entry.Outcome)
+				aggregate.Counts[entry.Outcome]++
+				if IsPanic(result.Err) {
+					aggregate.Panicked = true
+				}
+				jsonResponse.Checks[j.key] = entry
+				checksM.Unlock()
 			}
-			checksM.Unlock()
-		}(key, check)
+		}()
 	}
 
 	wg.Wait() // Wait for all checks to finish.
 
-	jsonResponse.Status = http.StatusText(jsonResponse.StatusCode)
+	jsonResponse.Outcome = aggregate.Outcome
+	jsonResponse.AbandonedChecks = s.stuck.count()
+	jsonResponse.StatusCode, jsonResponse.Status = s.statusPolicy.Status(aggregate)
+	if jsonResponse.Status == "" {
+		jsonResponse.Status = http.StatusText(jsonResponse.StatusCode)
+	}
+
+	s.observers.evaluationFinished(ctx, kind, jsonResponse)
 
 	return jsonResponse
 }
 
-func errorToStatus(code int, err error) int {
-	if strings.HasPrefix(err.Error(), ErrCheckerPanic.Error()) {
-		return http.StatusInternalServerError
+// runWithTimeout runs the check until it finishes, the timeout set by WithTimeout elapses or the context is done. The
+// timeout starts when the check starts, so the checks waiting for WithMaxParallelism get their full timeout. When the
+// check does not finish in time, it is left running and no new runs of it are started until it finishes.
+func (s *Healthcheck) runWithTimeout(ctx context.Context, key checkKey, check Checker) CheckResult {
+	if err := ctx.Err(); err != nil {
+		return CheckResult{Err: err}
 	}
-	if code == http.StatusOK {
-		return http.StatusServiceUnavailable
+	if s.stuck.isStuck(key) {
+		return CheckResult{Err: ErrCheckStillRunning}
+	}
+
+	if s.checkerTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.checkerTimeout)
+		defer cancel()
+	}
+
+	run := &checkRun{key: key}
+	resultch := make(chan CheckResult, 1)
+
+	// Start another goroutine to be able to track timeouts.
+	go func() {
+		defer s.stuck.finish(run)
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			handlerRecover(r, resultch)
+		}()
+
+		resultch <- runCheck(ctx, check)
+	}()
+
+	select {
+	case <-ctx.Done(): // timeout
+		s.stuck.abandon(run)
+		return CheckResult{Err: ctx.Err()}
+	case result := <-resultch:
+		return result
 	}
-	return code
+}
+
+// publicResponse applies the redactors and the detail level of the request to the response. Authenticated requests
+// receive the full detail, the others receive the level set by WithPublicDetail.
+func (s *Healthcheck) publicResponse(ctx context.Context, r *CheckResponse) *CheckResponse {
+	level := DetailFull
+	if !IsAuthenticated(ctx) {
+		level = s.publicDetail
+	}
+	if level > DetailFull && s.detailLogger != nil {
+		s.detailLogger(ctx, r)
+	}
+	if len(s.redactors) > 0 && level < DetailNoErrors {
+		public := *r
+		public.Checks = redactEntries(r.Checks, s.redactors, level)
+		return &public
+	}
+	return r.WithDetail(level)
 }
 
 // handlerRecover handles a possible panic from the handler implementation.
-func handlerRecover(r interface{}, errch chan<- error) {
+func handlerRecover(r interface{}, resultch chan<- CheckResult) {
 	if r == nil {
 		return
 	}
 
-	errch <- panicError(r)
+	resultch <- CheckResult{Err: NewPanicError(r)}
 }
 
 // errorMessage returns the error message for the given error. If the error is nil, the message returned is empty.
@@ -150,14 +376,3 @@ func errorMessage(err error) string {
 	}
 	return err.Error()
 }
-
-// panicError returns the panic message for the given panic. If the recover data is an error, the method
-// errorMessage is used.
-func panicError(r interface{}) error {
-	err, ok := r.(error)
-	if ok {
-		r = err.Error()
-	}
-
-	return fmt.Errorf("%s: %v", ErrCheckerPanic, r)
-}
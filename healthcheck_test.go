@@ -6,7 +6,9 @@ package svchealthcheck
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -17,12 +19,12 @@ import (
 
 func Test_panicErrorMessage(t *testing.T) {
 	t.Run("should return a string given a non-error", func(t *testing.T) {
-		got := panicError("something wrong happened")
+		got := NewPanicError("something wrong happened")
 		assert.Equal(t, "checker panicked: something wrong happened", got.Error())
 	})
 
 	t.Run("should return a string given an error", func(t *testing.T) {
-		got := panicError(errors.New("something wrong happened"))
+		got := NewPanicError(errors.New("something wrong happened"))
 		assert.Equal(t, "checker panicked: something wrong happened", got.Error())
 	})
 }
@@ -48,12 +50,12 @@ func Test_handlerRecover(t *testing.T) {
 	t.Run("should set an error if a panic happened", func(t *testing.T) {
 		err := errors.New("something wrong happened")
 
-		errch := make(chan error, 1)
-		handlerRecover(err, errch)
+		resultch := make(chan CheckResult, 1)
+		handlerRecover(err, resultch)
 
 		select {
-		case err = <-errch:
-			assert.Contains(t, err.Error(), ErrCheckerPanic.Error())
+		case result := <-resultch:
+			assert.Contains(t, result.Err.Error(), ErrCheckerPanic.Error())
 		default:
 			require.Fail(t, "should have received an error")
 			return
@@ -94,7 +96,7 @@ func TestHealthcheck_generateResponse(t *testing.T) {
 
 		hc := NewHealthcheck()
 
-		response := hc.generateResponse(context.Background(), map[string]Checker{
+		response := hc.generateResponse(context.Background(), KindHealth, map[string]Checker{
 			"check1": mockChecker1,
 			"check2": mockChecker2,
 			"check3": mockChecker3,
@@ -141,7 +143,7 @@ func TestHealthcheck_generateResponse(t *testing.T) {
 
 		hc := NewHealthcheck()
 
-		response := hc.generateResponse(context.Background(), map[string]Checker{
+		response := hc.generateResponse(context.Background(), KindHealth, map[string]Checker{
 			"check1": mockChecker1,
 			"check2": mockChecker2,
 			"check3": mockChecker3,
@@ -186,7 +188,7 @@ func TestHealthcheck_generateResponse(t *testing.T) {
 
 		hc := NewHealthcheck()
 
-		response := hc.generateResponse(context.Background(), map[string]Checker{
+		response := hc.generateResponse(context.Background(), KindHealth, map[string]Checker{
 			"check1": mockChecker1,
 			"check2": mockChecker2,
 			"check3": mockChecker3,
@@ -232,7 +234,7 @@ func TestHealthcheck_generateResponse(t *testing.T) {
 		wantTimeout := wantDuration1 + time.Millisecond*50
 		hc := NewHealthcheck(WithTimeout(wantTimeout))
 
-		response := hc.generateResponse(context.Background(), map[string]Checker{
+		response := hc.generateResponse(context.Background(), KindHealth, map[string]Checker{
 			"check1": mockChecker1,
 			"check2": mockChecker2,
 			"check3": mockChecker3,
@@ -250,6 +252,88 @@ func TestHealthcheck_generateResponse(t *testing.T) {
 	})
 }
 
+func TestHealthcheck_generateResponse_detailed(t *testing.T) {
+	hc := NewHealthcheck()
+
+	response := hc.generateResponse(context.Background(), KindHealth, map[string]Checker{
+		"shards": DetailedCheckerFunc(func(ctx context.Context) CheckResult {
+			return CheckResult{
+				Err:           errors.New("shard2 is down"),
+				ObservedValue: 1,
+				ObservedUnit:  "shards",
+				Details:       map[string]interface{}{"total": 2},
+				Components: map[string]CheckResult{
+					"shard1": {Duration: time.Millisecond},
+					"shard2": {Err: errors.New("connection refused")},
+				},
+			}
+		}),
+	})
+
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	entry := response.Checks["shards"]
+	assert.Equal(t, "shard2 is down", entry.Error)
+	assert.NotEmpty(t, entry.Duration)
+	assert.Equal(t, 1, entry.ObservedValue)
+	assert.Equal(t, "shards", entry.ObservedUnit)
+	assert.Equal(t, map[string]interface{}{"total": 2}, entry.Details)
+	assert.Equal(t, map[string]CheckResponseEntry{
+		"shard1": {Outcome: OutcomePass, Duration: time.Millisecond.String()},
+		"shard2": {Outcome: OutcomeFail, Error: "connection refused"},
+	}, entry.Components)
+}
+
+func TestHealthcheck_generateResponse_outcomes(t *testing.T) {
+	checkers := func(errs ...error) map[string]Checker {
+		r := make(map[string]Checker, len(errs))
+		for i, err := range errs {
+			err := err
+			r[fmt.Sprintf("check%d", i)] = CheckerFunc(func(ctx context.Context) error {
+				return err
+			})
+		}
+		return r
+	}
+
+	t.Run("should return ok when a check warns", func(t *testing.T) {
+		response := NewHealthcheck().generateResponse(context.Background(), KindHealth, checkers(nil, fmt.Errorf("%w: almost full", ErrWarn)))
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, OutcomeWarn, response.Outcome)
+		assert.Equal(t, OutcomePass, response.Checks["check0"].Outcome)
+		assert.Equal(t, OutcomeWarn, response.Checks["check1"].Outcome)
+		assert.Equal(t, "warning: almost full", response.Checks["check1"].Error)
+	})
+
+	t.Run("should return ok when a check is skipped", func(t *testing.T) {
+		response := NewHealthcheck().generateResponse(context.Background(), KindHealth, checkers(ErrSkipped))
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, OutcomeSkip, response.Outcome)
+	})
+
+	t.Run("should use the worst outcome", func(t *testing.T) {
+		response := NewHealthcheck().generateResponse(context.Background(), KindHealth, checkers(ErrWarn, errors.New("failed"), ErrUnknown))
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		assert.Equal(t, OutcomeFail, response.Outcome)
+	})
+
+	t.Run("should use the configured status code", func(t *testing.T) {
+		hc := NewHealthcheck(WithOutcomeStatusCode(OutcomeWarn, http.StatusTooManyRequests))
+		response := hc.generateResponse(context.Background(), KindHealth, checkers(ErrWarn))
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+		assert.Equal(t, "Too Many Requests", response.Status)
+	})
+
+	t.Run("should use the explicit outcome of a detailed checker", func(t *testing.T) {
+		response := NewHealthcheck().generateResponse(context.Background(), KindHealth, map[string]Checker{
+			"check": DetailedCheckerFunc(func(ctx context.Context) CheckResult {
+				return CheckResult{Err: errors.New("replica lagging"), Outcome: OutcomeWarn}
+			}),
+		})
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, OutcomeWarn, response.Checks["check"].Outcome)
+	})
+}
+
 func mustParseDuration(t *testing.T, s string) time.Duration {
 	t.Helper()
 	d, err := time.ParseDuration(s)
@@ -320,3 +404,167 @@ func TestHealthcheck_Ready(t *testing.T) {
 	assert.Empty(t, response.Checks["check2"].Error)
 	assert.Empty(t, response.Checks["check3"].Error)
 }
+
+func TestHealthcheck_Ready_includesHealth(t *testing.T) {
+	t.Run("should run the health checks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockLiveness := NewMockChecker(ctrl)
+		mockQueue := NewMockChecker(ctrl)
+
+		mockLiveness.EXPECT().
+			Check(gomock.Any()).
+			Return(errors.New("deadlocked"))
+
+		mockQueue.EXPECT().
+			Check(gomock.Any()).
+			Return(nil)
+
+		hc := NewHealthcheck(
+			WithReadyIncludesHealth(),
+			WithCheck("liveness", mockLiveness),
+			WithReadyCheck("queue", mockQueue),
+		)
+
+		response := hc.Ready(context.Background())
+
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		require.Len(t, response.Checks, 2)
+		assert.Equal(t, "deadlocked", response.Checks["liveness"].Error)
+		assert.Equal(t, []CheckKind{KindHealth}, response.Checks["liveness"].Kinds)
+		assert.Equal(t, []CheckKind{KindReady}, response.Checks["queue"].Kinds)
+	})
+
+	t.Run("should run a check registered in both sets once", func(t *testing.T) {
+		var calls atomic.Int32
+		database := CheckerFunc(func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		})
+
+		hc := NewHealthcheck(
+			WithReadyIncludesHealth(),
+			WithCheck("database", database),
+			WithReadyCheck("database", database),
+		)
+
+		response := hc.Ready(context.Background())
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		require.Len(t, response.Checks, 1)
+		assert.Equal(t, []CheckKind{KindHealth, KindReady}, response.Checks["database"].Kinds)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should run the ready checker when the sets share a name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockHealthDatabase := NewMockChecker(ctrl)
+		mockReadyDatabase := NewMockChecker(ctrl)
+
+		mockReadyDatabase.EXPECT().
+			Check(gomock.Any()).
+			Return(errors.New("connection refused"))
+
+		hc := NewHealthcheck(
+			WithReadyIncludesHealth(),
+			WithCheck("database", mockHealthDatabase),
+			WithReadyCheck("database", mockReadyDatabase),
+		)
+
+		response := hc.Ready(context.Background())
+
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		require.Len(t, response.Checks, 1)
+		assert.Equal(t, "connection refused", response.Checks["database"].Error)
+		assert.Equal(t, []CheckKind{KindHealth, KindReady}, response.Checks["database"].Kinds)
+	})
+}
+
+func TestHealthcheck_Ready_withoutHealth(t *testing.T) {
+	hc := NewHealthcheck(
+		WithCheck("liveness", CheckerFunc(func(ctx context.Context) error {
+			return errors.New("deadlocked")
+		})),
+		WithReadyCheck("queue", CheckerFunc(func(ctx context.Context) error {
+			return nil
+		})),
+	)
+
+	response := hc.Ready(context.Background())
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	require.Len(t, response.Checks, 1)
+	assert.Empty(t, response.Checks["queue"].Kinds, "the entries should only be marked when the sets are combined")
+}
+
+func TestHealthcheck_Check(t *testing.T) {
+	var databaseRuns, cacheRuns atomic.Int32
+	hc := NewHealthcheck(
+		WithCheck("database", CheckerFunc(func(ctx context.Context) error {
+			databaseRuns.Add(1)
+			return errors.New("connection refused")
+		})),
+		WithCheck("cache", CheckerFunc(func(ctx context.Context) error {
+			cacheRuns.Add(1)
+			return nil
+		})),
+	)
+
+	t.Run("should evaluate only the named check", func(t *testing.T) {
+		r, ok := hc.Check(context.Background(), KindHealth, "database")
+		require.True(t, ok)
+		assert.Equal(t, http.StatusServiceUnavailable, r.StatusCode)
+		require.Len(t, r.Checks, 1)
+		assert.Equal(t, "connection refused", r.Checks["database"].Error)
+		assert.Equal(t, int32(1), databaseRuns.Load())
+		assert.Equal(t, int32(0), cacheRuns.Load())
+	})
+
+	t.Run("should return false for unknown checks", func(t *testing.T) {
+		_, ok := hc.Check(context.Background(), KindReady, "database")
+		assert.False(t, ok)
+		_, ok = hc.Check(context.Background(), CheckKind("other"), "database")
+		assert.False(t, ok)
+	})
+
+	t.Run("should reject when the limits are exceeded", func(t *testing.T) {
+		hc := NewHealthcheck(WithCheck("database", CheckerFunc(func(ctx context.Context) error {
+			return nil
+		})), WithRateLimit(0.001, 1))
+
+		r, ok := hc.Check(context.Background(), KindHealth, "database")
+		require.True(t, ok)
+		assert.Equal(t, http.StatusOK, r.StatusCode)
+
+		r, ok = hc.Check(context.Background(), KindHealth, "database")
+		require.True(t, ok)
+		assert.Equal(t, http.StatusTooManyRequests, r.StatusCode)
+		assert.False(t, r.Cached)
+	})
+}
+
+func TestHealthcheck_CheckTag(t *testing.T) {
+	check := CheckerFunc(func(ctx context.Context) error {
+		return nil
+	})
+	hc := NewHealthcheck(
+		WithCheck("database", check),
+		WithCheck("cache", check),
+		WithCheck("queue", check),
+		WithReadyCheck("database", check),
+		WithTags("database", "storage"),
+		WithTags("cache", "storage", "memory"),
+	)
+
+	r, ok := hc.CheckTag(context.Background(), KindHealth, "storage")
+	require.True(t, ok)
+	assert.Len(t, r.Checks, 2)
+	assert.Contains(t, r.Checks, "database")
+	assert.Contains(t, r.Checks, "cache")
+
+	r, ok = hc.CheckTag(context.Background(), KindReady, "storage")
+	require.True(t, ok)
+	assert.Len(t, r.Checks, 1)
+
+	_, ok = hc.CheckTag(context.Background(), KindReady, "memory")
+	assert.False(t, ok)
+}
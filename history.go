@@ -0,0 +1,158 @@
+package svchealthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HistoryEntry is the result of a single run of a check.
+type HistoryEntry struct {
+	Time     time.Time `json:"time"`
+	Kind     CheckKind `json:"kind"`
+	Outcome  Outcome   `json:"outcome"`
+	Duration string    `json:"duration,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// CheckHistory holds the most recent results of a check, from the oldest to the newest, and their summary.
+type CheckHistory struct {
+	// Uptime is the percentage of the entries that did not fail.
+	Uptime float64 `json:"uptime"`
+	// LastFailure is the time of the last failure of the check, even if it is no longer part of the entries.
+	LastFailure *time.Time     `json:"lastFailure,omitempty"`
+	Entries     []HistoryEntry `json:"entries,omitempty"`
+}
+
+// WithDetail returns the history reduced to the given DetailLevel. DetailNoErrors removes the error messages and
+// DetailStatusOnly keeps only the summary. The history itself is not modified.
+func (h CheckHistory) WithDetail(level DetailLevel) CheckHistory {
+	switch {
+	case level <= DetailFull:
+		return h
+	case level >= DetailStatusOnly:
+		h.Entries = nil
+		return h
+	}
+	entries := make([]HistoryEntry, len(h.Entries))
+	for i, entry := range h.Entries {
+		entry.Error = ""
+		entries[i] = entry
+	}
+	h.Entries = entries
+	return h
+}
+
+// historyRing is the bounded history of a single check.
+type historyRing struct {
+	entries     []HistoryEntry
+	next        int
+	lastFailure time.Time
+}
+
+// history keeps the historyRing of each check. A zero size disables it.
+type history struct {
+	size  int
+	mu    sync.RWMutex
+	rings map[string]*historyRing
+}
+
+func newHistory(size int) *history {
+	return &history{
+		size:  size,
+		rings: make(map[string]*historyRing),
+	}
+}
+
+// record adds the entry to the history of the check, replacing the oldest one when the history is full.
+func (h *history) record(name string, entry HistoryEntry) {
+	if h.size <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ring, ok := h.rings[name]
+	if !ok {
+		ring = &historyRing{entries: make([]HistoryEntry, 0, h.size)}
+		h.rings[name] = ring
+	}
+	if len(ring.entries) < h.size {
+		ring.entries = append(ring.entries, entry)
+	} else {
+		ring.entries[ring.next] = entry
+		ring.next = (ring.next + 1) % h.size
+	}
+	if entry.Outcome.Failed() {
+		ring.lastFailure = entry.Time
+	}
+}
+
+func (h *history) get(name string) (CheckHistory, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ring, ok := h.rings[name]
+	if !ok {
+		return CheckHistory{}, false
+	}
+	return ring.snapshot(), true
+}
+
+func (h *history) all() map[string]CheckHistory {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	r := make(map[string]CheckHistory, len(h.rings))
+	for name, ring := range h.rings {
+		r[name] = ring.snapshot()
+	}
+	return r
+}
+
+// snapshot copies the entries, from the oldest to the newest, and computes the summary.
+func (r *historyRing) snapshot() CheckHistory {
+	entries := make([]HistoryEntry, 0, len(r.entries))
+	entries = append(entries, r.entries[r.next:]...)
+	entries = append(entries, r.entries[:r.next]...)
+
+	passed := 0
+	for _, entry := range entries {
+		if !entry.Outcome.Failed() {
+			passed++
+		}
+	}
+
+	h := CheckHistory{
+		Uptime:  100,
+		Entries: entries,
+	}
+	if len(entries) > 0 {
+		h.Uptime = float64(passed) * 100 / float64(len(entries))
+	}
+	if !r.lastFailure.IsZero() {
+		lastFailure := r.lastFailure
+		h.LastFailure = &lastFailure
+	}
+	return h
+}
+
+// History returns the recent results of the check with the given name, from both the health and ready checks. It
+// returns false if the check has not run yet or the history is disabled by WithHistorySize.
+func (s *Healthcheck) History(name string) (CheckHistory, bool) {
+	return s.history.get(name)
+}
+
+// Histories returns the histories of all the checks that have run, reduced to the detail level of the request like
+// the responses of Health and Ready.
+func (s *Healthcheck) Histories(ctx context.Context) map[string]CheckHistory {
+	r := s.history.all()
+	if IsAuthenticated(ctx) || s.publicDetail <= DetailFull {
+		return r
+	}
+	for name, h := range r {
+		r[name] = h.WithDetail(s.publicDetail)
+	}
+	return r
+}
@@ -0,0 +1,130 @@
+package svchealthcheck
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_history(t *testing.T) {
+	base := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(i int, outcome Outcome) HistoryEntry {
+		return HistoryEntry{Time: base.Add(time.Duration(i) * time.Second), Kind: KindHealth, Outcome: outcome}
+	}
+
+	t.Run("should return false for unknown checks", func(t *testing.T) {
+		h := newHistory(3)
+		_, ok := h.get("db")
+		assert.False(t, ok)
+	})
+
+	t.Run("should keep the entries from the oldest to the newest", func(t *testing.T) {
+		h := newHistory(3)
+		h.record("db", entry(0, OutcomePass))
+		h.record("db", entry(1, OutcomeFail))
+
+		got, ok := h.get("db")
+		require.True(t, ok)
+		require.Len(t, got.Entries, 2)
+		assert.Equal(t, base, got.Entries[0].Time)
+		assert.Equal(t, base.Add(time.Second), got.Entries[1].Time)
+	})
+
+	t.Run("should drop the oldest entries when full", func(t *testing.T) {
+		h := newHistory(3)
+		for i := 0; i < 5; i++ {
+			h.record("db", entry(i, OutcomePass))
+		}
+
+		got, _ := h.get("db")
+		require.Len(t, got.Entries, 3)
+		assert.Equal(t, base.Add(2*time.Second), got.Entries[0].Time)
+		assert.Equal(t, base.Add(3*time.Second), got.Entries[1].Time)
+		assert.Equal(t, base.Add(4*time.Second), got.Entries[2].Time)
+	})
+
+	t.Run("should summarize the uptime and the last failure", func(t *testing.T) {
+		h := newHistory(4)
+		h.record("db", entry(0, OutcomeFail))
+		for i := 1; i < 4; i++ {
+			h.record("db", entry(i, OutcomePass))
+		}
+
+		got, _ := h.get("db")
+		assert.Equal(t, float64(75), got.Uptime)
+		require.NotNil(t, got.LastFailure)
+		assert.Equal(t, base, *got.LastFailure)
+
+		h.record("db", entry(4, OutcomeWarn))
+		got, _ = h.get("db")
+		assert.Equal(t, float64(100), got.Uptime, "warnings should not count as failures")
+		require.NotNil(t, got.LastFailure, "the last failure should be kept after the entry is dropped")
+		assert.Equal(t, base, *got.LastFailure)
+	})
+
+	t.Run("should not record when disabled", func(t *testing.T) {
+		h := newHistory(0)
+		h.record("db", entry(0, OutcomePass))
+		assert.Empty(t, h.all())
+	})
+}
+
+func TestCheckHistory_WithDetail(t *testing.T) {
+	h := CheckHistory{
+		Uptime:  50,
+		Entries: []HistoryEntry{{Outcome: OutcomeFail, Error: "connection refused"}},
+	}
+
+	assert.Equal(t, h, h.WithDetail(DetailFull))
+
+	noErrors := h.WithDetail(DetailNoErrors)
+	require.Len(t, noErrors.Entries, 1)
+	assert.Empty(t, noErrors.Entries[0].Error)
+	assert.Equal(t, "connection refused", h.Entries[0].Error, "the original history should not be modified")
+
+	statusOnly := h.WithDetail(DetailStatusOnly)
+	assert.Nil(t, statusOnly.Entries)
+	assert.Equal(t, float64(50), statusOnly.Uptime)
+}
+
+func TestHealthcheck_History(t *testing.T) {
+	ctx := context.Background()
+	hc := NewHealthcheck(
+		WithRedactor(RegexRedactor(regexp.MustCompile(`secret`))),
+		WithPublicDetail(DetailStatusOnly),
+		WithCheck("db", CheckerFunc(func(ctx context.Context) error {
+			return errors.New("password secret rejected")
+		})),
+		WithReadyCheck("db", CheckerFunc(func(ctx context.Context) error {
+			return nil
+		})),
+	)
+
+	hc.Health(ctx)
+	hc.Ready(ctx)
+
+	got, ok := hc.History("db")
+	require.True(t, ok)
+	require.Len(t, got.Entries, 2)
+	assert.Equal(t, KindHealth, got.Entries[0].Kind)
+	assert.Equal(t, OutcomeFail, got.Entries[0].Outcome)
+	assert.Equal(t, "password [REDACTED] rejected", got.Entries[0].Error)
+	assert.NotEmpty(t, got.Entries[0].Duration)
+	assert.Equal(t, KindReady, got.Entries[1].Kind)
+	assert.Equal(t, float64(50), got.Uptime)
+	assert.NotNil(t, got.LastFailure)
+
+	t.Run("should reduce the histories to the public detail level", func(t *testing.T) {
+		public := hc.Histories(ctx)
+		require.Contains(t, public, "db")
+		assert.Nil(t, public["db"].Entries)
+
+		full := hc.Histories(ContextWithAuthenticated(ctx))
+		assert.Len(t, full["db"].Entries, 2)
+	})
+}
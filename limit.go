@@ -0,0 +1,129 @@
+package svchealthcheck
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LimitPolicy defines what is returned when a request exceeds the limits set by WithRateLimit or
+// WithMaxConcurrentEvaluations.
+type LimitPolicy int
+
+const (
+	// LimitServeLast returns the most recent response, marked as cached. When there is none yet, or it is older than
+	// the duration set by WithMaxStaleness, the request is rejected as in LimitReject.
+	LimitServeLast LimitPolicy = iota
+	// LimitReject returns a response with http.StatusTooManyRequests without running the checks.
+	LimitReject
+)
+
+// limiter caps the number of evaluations of the checks per second and running at the same time. Health and Ready have
+// a limiter each, so the requests of one do not consume the limits of the other.
+type limiter struct {
+	policy   LimitPolicy
+	maxStale time.Duration
+	sem      chan struct{}
+	last     atomic.Pointer[lastResponse]
+
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+func newLimiter(o *options) *limiter {
+	if o.maxConcurrent <= 0 && o.ratePerSecond <= 0 {
+		return nil
+	}
+	l := &limiter{
+		policy:   o.limitPolicy,
+		maxStale: o.maxStaleness,
+		rate:     o.ratePerSecond,
+		burst:    float64(o.rateBurst),
+	}
+	if l.burst < 1 {
+		l.burst = 1
+	}
+	l.tokens = l.burst
+	if o.maxConcurrent > 0 {
+		l.sem = make(chan struct{}, o.maxConcurrent)
+	}
+	return l
+}
+
+// acquire returns a release function and true if a new evaluation is allowed.
+func (l *limiter) acquire() (func(), bool) {
+	if !l.allowRate(time.Now()) {
+		return nil, false
+	}
+	if l.sem == nil {
+		return func() {}, true
+	}
+	select {
+	case l.sem <- struct{}{}:
+		return func() {
+			<-l.sem
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// allowRate implements a token bucket with the configured rate and burst.
+func (l *limiter) allowRate(now time.Time) bool {
+	if l.rate <= 0 {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.lastFill.IsZero() {
+		l.tokens += now.Sub(l.lastFill).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.lastFill = now
+
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// lastResponse is the most recent response generated and when it was generated.
+type lastResponse struct {
+	response *CheckResponse
+	at       time.Time
+}
+
+// store keeps the response to be served to the requests that exceed the limits.
+func (l *limiter) store(r *CheckResponse, now time.Time) {
+	l.last.Store(&lastResponse{response: r, at: now})
+}
+
+// limitedResponse returns the response for a request that exceeded the limits.
+func (l *limiter) limitedResponse(now time.Time) *CheckResponse {
+	if l.policy == LimitServeLast {
+		if last := l.last.Load(); last != nil && (l.maxStale <= 0 || now.Sub(last.at) <= l.maxStale) {
+			cached := *last.response
+			cached.Cached = true
+			return &cached
+		}
+	}
+	return rateLimitedResponse()
+}
+
+// rateLimitedResponse returns the response rejecting a request that exceeded the limits.
+func rateLimitedResponse() *CheckResponse {
+	return &CheckResponse{
+		StatusCode: http.StatusTooManyRequests,
+		Status:     http.StatusText(http.StatusTooManyRequests),
+		Outcome:    OutcomeUnknown,
+	}
+}
@@ -0,0 +1,195 @@
+package svchealthcheck
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newLimiter(t *testing.T) {
+	t.Run("should not create a limiter when no limits are set", func(t *testing.T) {
+		opts := defaultOpts()
+		assert.Nil(t, newLimiter(&opts))
+	})
+
+	t.Run("should create a limiter with a minimum burst of 1", func(t *testing.T) {
+		opts := defaultOpts()
+		WithRateLimit(10, 0)(&opts)
+		l := newLimiter(&opts)
+		require.NotNil(t, l)
+		assert.Equal(t, float64(1), l.burst)
+		assert.Nil(t, l.sem)
+	})
+}
+
+func Test_limiter_allowRate(t *testing.T) {
+	l := &limiter{rate: 2, burst: 2, tokens: 2}
+	now := time.Now()
+
+	assert.True(t, l.allowRate(now))
+	assert.True(t, l.allowRate(now))
+	assert.False(t, l.allowRate(now))
+	assert.False(t, l.allowRate(now.Add(time.Millisecond*100)))
+	assert.True(t, l.allowRate(now.Add(time.Millisecond*600)))
+	assert.False(t, l.allowRate(now.Add(time.Millisecond*600)))
+	assert.True(t, l.allowRate(now.Add(time.Hour)))
+	assert.True(t, l.allowRate(now.Add(time.Hour)))
+	assert.False(t, l.allowRate(now.Add(time.Hour)), "the tokens should be capped by the burst")
+}
+
+func Test_limiter_acquire(t *testing.T) {
+	l := &limiter{sem: make(chan struct{}, 1)}
+
+	release, ok := l.acquire()
+	require.True(t, ok)
+
+	_, ok = l.acquire()
+	assert.False(t, ok)
+
+	release()
+	_, ok = l.acquire()
+	assert.True(t, ok)
+}
+
+func Test_limiter_limitedResponse(t *testing.T) {
+	now := time.Now()
+	last := &CheckResponse{StatusCode: http.StatusOK, Status: "OK"}
+
+	t.Run("should serve the last response within the max staleness", func(t *testing.T) {
+		l := &limiter{maxStale: time.Minute}
+		l.store(last, now)
+		r := l.limitedResponse(now.Add(time.Minute))
+		assert.Equal(t, http.StatusOK, r.StatusCode)
+		assert.True(t, r.Cached)
+		assert.False(t, last.Cached, "the stored response should not be modified")
+	})
+
+	t.Run("should reject when the last response is older than the max staleness", func(t *testing.T) {
+		l := &limiter{maxStale: time.Minute}
+		l.store(last, now)
+		r := l.limitedResponse(now.Add(time.Minute + time.Second))
+		assert.Equal(t, http.StatusTooManyRequests, r.StatusCode)
+	})
+
+	t.Run("should serve the last response of any age when there is no max staleness", func(t *testing.T) {
+		l := &limiter{}
+		l.store(last, now)
+		r := l.limitedResponse(now.Add(time.Hour * 24))
+		assert.Equal(t, http.StatusOK, r.StatusCode)
+	})
+}
+
+func TestHealthcheck_rateLimit(t *testing.T) {
+	var calls atomic.Int32
+	check := CheckerFunc(func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+
+	t.Run("should serve the last response when the rate is exceeded", func(t *testing.T) {
+		calls.Store(0)
+		hc := NewHealthcheck(WithCheck("check", check), WithRateLimit(0.001, 1))
+
+		first := hc.Health(context.Background())
+		assert.False(t, first.Cached)
+
+		second := hc.Health(context.Background())
+		assert.True(t, second.Cached)
+		assert.Equal(t, http.StatusOK, second.StatusCode)
+		assert.Contains(t, second.Checks, "check")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should reject when there is no last response", func(t *testing.T) {
+		calls.Store(0)
+		hc := NewHealthcheck(WithCheck("check", check), WithRateLimit(0.001, 1), WithLimitPolicy(LimitServeLast))
+
+		hc.Health(context.Background())
+		hc.healthLimiter.last.Store(nil)
+		response := hc.Health(context.Background())
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+		assert.Equal(t, "Too Many Requests", response.Status)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should limit Health and Ready independently", func(t *testing.T) {
+		calls.Store(0)
+		hc := NewHealthcheck(WithCheck("check", check), WithReadyCheck("check", check), WithRateLimit(0.001, 1))
+
+		ready := hc.Ready(context.Background())
+		assert.Equal(t, http.StatusOK, ready.StatusCode)
+		assert.False(t, ready.Cached)
+
+		health := hc.Health(context.Background())
+		assert.Equal(t, http.StatusOK, health.StatusCode)
+		assert.False(t, health.Cached)
+		assert.Equal(t, int32(2), calls.Load())
+
+		ready = hc.Ready(context.Background())
+		assert.True(t, ready.Cached)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("should reject when the last response is too old", func(t *testing.T) {
+		calls.Store(0)
+		hc := NewHealthcheck(WithCheck("check", check), WithRateLimit(0.001, 1), WithMaxStaleness(time.Minute))
+
+		hc.Health(context.Background())
+		hc.healthLimiter.store(hc.healthLimiter.last.Load().response, time.Now().Add(-time.Minute*2))
+		response := hc.Health(context.Background())
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+		assert.False(t, response.Cached)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should reject when the policy is LimitReject", func(t *testing.T) {
+		hc := NewHealthcheck(WithCheck("check", check), WithRateLimit(0.001, 1), WithLimitPolicy(LimitReject))
+
+		hc.Health(context.Background())
+		response := hc.Health(context.Background())
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+		assert.False(t, response.Cached)
+	})
+}
+
+func TestHealthcheck_maxConcurrentEvaluations(t *testing.T) {
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	var calls atomic.Int32
+	hc := NewHealthcheck(
+		WithCheck("check", CheckerFunc(func(ctx context.Context) error {
+			if calls.Add(1) == 1 {
+				close(started)
+				<-unblock
+			}
+			return nil
+		})),
+		WithMaxConcurrentEvaluations(1),
+		WithLimitPolicy(LimitReject),
+	)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		response := hc.Health(context.Background())
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	}()
+
+	<-started
+	response := hc.Health(context.Background())
+	assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+
+	close(unblock)
+	wg.Wait()
+
+	response = hc.Health(context.Background())
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,141 @@
+package svchealthcheck
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// logObserver is the Observer that logs the state transitions, panics and slow checks. Repeated logs about the same
+// check are limited to one per interval.
+type logObserver struct {
+	logger        *slog.Logger
+	slowThreshold time.Duration
+	interval      time.Duration
+	now           func() time.Time
+
+	mu       sync.Mutex
+	outcomes map[string]Outcome
+	lastLogs map[string]time.Time
+}
+
+func newLogObserver(logger *slog.Logger, slowThreshold, interval time.Duration) *logObserver {
+	return &logObserver{
+		logger:        logger,
+		slowThreshold: slowThreshold,
+		interval:      interval,
+		now:           time.Now,
+		outcomes:      make(map[string]Outcome),
+		lastLogs:      make(map[string]time.Time),
+	}
+}
+
+// EvaluationStarted implements the Observer interface.
+func (o *logObserver) EvaluationStarted(ctx context.Context, _ CheckKind) context.Context {
+	return ctx
+}
+
+// EvaluationFinished implements the Observer interface, logging the transitions of the overall outcome.
+func (o *logObserver) EvaluationFinished(ctx context.Context, kind CheckKind, response *CheckResponse) {
+	previous, changed := o.transition(string(kind), response.Outcome)
+	if !changed {
+		return
+	}
+	o.logger.Log(ctx, levelFor(response.Outcome), "health state changed",
+		slog.String("kind", string(kind)),
+		slog.String("from", string(previous)),
+		slog.String("to", string(response.Outcome)),
+		slog.Int("statusCode", response.StatusCode),
+	)
+}
+
+// CheckStarted implements the Observer interface.
+func (o *logObserver) CheckStarted(ctx context.Context, _ CheckKind, _ string) context.Context {
+	return ctx
+}
+
+// CheckFinished implements the Observer interface, logging panics, slow checks and the transitions of the outcome of
+// the check.
+func (o *logObserver) CheckFinished(ctx context.Context, kind CheckKind, name string, result CheckResult, duration time.Duration) {
+	key := string(kind) + "/" + name
+	outcome := result.EffectiveOutcome()
+	attrs := []slog.Attr{
+		slog.String("kind", string(kind)),
+		slog.String("check", name),
+		slog.String("outcome", string(outcome)),
+		slog.Duration("duration", duration),
+	}
+	if result.Err != nil {
+		attrs = append(attrs, slog.String("error", result.Err.Error()))
+	}
+
+	if IsPanic(result.Err) && o.allow(key+"/panic") {
+		o.logger.LogAttrs(ctx, slog.LevelError, "health check panicked", append(attrs, slog.String("stack", string(PanicStack(result.Err))))...)
+	}
+
+	if o.slowThreshold > 0 && duration > o.slowThreshold && o.allow(key+"/slow") {
+		o.logger.LogAttrs(ctx, slog.LevelWarn, "slow health check", append(attrs, slog.Duration("threshold", o.slowThreshold))...)
+	}
+
+	previous, changed := o.transition(key, outcome)
+	switch {
+	case changed:
+		o.allow(key + "/state") // A transition restarts the interval of the repeated logs.
+		o.logger.LogAttrs(ctx, levelFor(outcome), "health check state changed", append(attrs, slog.String("from", string(previous)))...)
+	case outcome != OutcomePass && o.allow(key+"/state"):
+		o.logger.LogAttrs(ctx, levelFor(outcome), "health check still not passing", attrs...)
+	}
+}
+
+// transition records the outcome for the key, returning the previous one and whether it changed. The first outcome
+// recorded is only reported as a change if it is not passing.
+func (o *logObserver) transition(key string, outcome Outcome) (Outcome, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	previous, ok := o.outcomes[key]
+	o.outcomes[key] = outcome
+	if !ok {
+		return OutcomePass, outcome != OutcomePass
+	}
+	return previous, previous != outcome
+}
+
+// allow returns true if nothing was logged for the key within the interval, and records the current time for it.
+func (o *logObserver) allow(key string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	now := o.now()
+	if last, ok := o.lastLogs[key]; ok && now.Sub(last) < o.interval {
+		return false
+	}
+	o.lastLogs[key] = now
+	return true
+}
+
+func levelFor(outcome Outcome) slog.Level {
+	switch {
+	case outcome.Failed():
+		return slog.LevelError
+	case outcome == OutcomeWarn:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// SlogDetailLogger returns a DetailLogger that logs the full responses, at the debug level, using the given logger.
+func SlogDetailLogger(logger *slog.Logger) DetailLogger {
+	return func(ctx context.Context, response *CheckResponse) {
+		attrs := make([]slog.Attr, 0, len(response.Checks)+1)
+		attrs = append(attrs, slog.String("status", response.Status))
+		for name, entry := range response.Checks {
+			attrs = append(attrs, slog.Group(name,
+				slog.String("outcome", string(entry.Outcome)),
+				slog.String("error", entry.Error),
+				slog.String("duration", entry.Duration),
+			))
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "health check response", attrs...)
+	}
+}
@@ -0,0 +1,168 @@
+package svchealthcheck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogObserver(slowThreshold, interval time.Duration) (*logObserver, *bytes.Buffer, *time.Time) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	o := newLogObserver(logger, slowThreshold, interval)
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	o.now = func() time.Time {
+		return now
+	}
+	return o, &buf, &now
+}
+
+func logLines(buf *bytes.Buffer) []string {
+	s := strings.TrimSpace(buf.String())
+	buf.Reset()
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+func Test_logObserver_CheckFinished(t *testing.T) {
+	ctx := context.Background()
+	failed := CheckResult{Err: errors.New("connection refused")}
+
+	t.Run("should not log a passing check", func(t *testing.T) {
+		o, buf, _ := newTestLogObserver(0, time.Minute)
+		o.CheckFinished(ctx, KindHealth, "db", CheckResult{}, time.Millisecond)
+		assert.Empty(t, logLines(buf))
+	})
+
+	t.Run("should log the state transitions", func(t *testing.T) {
+		o, buf, _ := newTestLogObserver(0, time.Minute)
+
+		o.CheckFinished(ctx, KindHealth, "db", failed, time.Millisecond)
+		lines := logLines(buf)
+		require.Len(t, lines, 1)
+		assert.Contains(t, lines[0], "level=ERROR")
+		assert.Contains(t, lines[0], `msg="health check state changed"`)
+		assert.Contains(t, lines[0], "check=db")
+		assert.Contains(t, lines[0], "from=pass")
+		assert.Contains(t, lines[0], "outcome=fail")
+		assert.Contains(t, lines[0], `error="connection refused"`)
+
+		o.CheckFinished(ctx, KindHealth, "db", CheckResult{}, time.Millisecond)
+		lines = logLines(buf)
+		require.Len(t, lines, 1)
+		assert.Contains(t, lines[0], "level=INFO")
+		assert.Contains(t, lines[0], "from=fail")
+		assert.Contains(t, lines[0], "outcome=pass")
+	})
+
+	t.Run("should rate limit the logs of a check that keeps failing", func(t *testing.T) {
+		o, buf, now := newTestLogObserver(0, time.Minute)
+
+		o.CheckFinished(ctx, KindHealth, "db", failed, time.Millisecond)
+		require.Len(t, logLines(buf), 1)
+
+		o.CheckFinished(ctx, KindHealth, "db", failed, time.Millisecond)
+		o.CheckFinished(ctx, KindHealth, "db", failed, time.Millisecond)
+		assert.Empty(t, logLines(buf))
+
+		*now = now.Add(time.Minute)
+		o.CheckFinished(ctx, KindHealth, "db", failed, time.Millisecond)
+		lines := logLines(buf)
+		require.Len(t, lines, 1)
+		assert.Contains(t, lines[0], `msg="health check still not passing"`)
+	})
+
+	t.Run("should keep the checks of different kinds apart", func(t *testing.T) {
+		o, buf, _ := newTestLogObserver(0, time.Minute)
+		o.CheckFinished(ctx, KindHealth, "db", failed, time.Millisecond)
+		o.CheckFinished(ctx, KindReady, "db", failed, time.Millisecond)
+		assert.Len(t, logLines(buf), 2)
+	})
+
+	t.Run("should log panics", func(t *testing.T) {
+		o, buf, _ := newTestLogObserver(0, time.Minute)
+		o.CheckFinished(ctx, KindHealth, "db", CheckResult{Err: NewPanicError("boom")}, time.Millisecond)
+		lines := logLines(buf)
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `msg="health check panicked"`)
+		assert.Contains(t, lines[0], "boom")
+		assert.Contains(t, lines[0], "stack=")
+		assert.Contains(t, lines[0], "goroutine")
+	})
+
+	t.Run("should log slow checks", func(t *testing.T) {
+		o, buf, _ := newTestLogObserver(time.Second, time.Minute)
+
+		o.CheckFinished(ctx, KindHealth, "db", CheckResult{}, time.Millisecond)
+		assert.Empty(t, logLines(buf))
+
+		o.CheckFinished(ctx, KindHealth, "db", CheckResult{}, 2*time.Second)
+		lines := logLines(buf)
+		require.Len(t, lines, 1)
+		assert.Contains(t, lines[0], "level=WARN")
+		assert.Contains(t, lines[0], `msg="slow health check"`)
+		assert.Contains(t, lines[0], "threshold=1s")
+
+		o.CheckFinished(ctx, KindHealth, "db", CheckResult{}, 2*time.Second)
+		assert.Empty(t, logLines(buf))
+	})
+}
+
+func Test_logObserver_EvaluationFinished(t *testing.T) {
+	ctx := context.Background()
+	o, buf, _ := newTestLogObserver(0, time.Minute)
+
+	o.EvaluationFinished(ctx, KindReady, &CheckResponse{StatusCode: 200, Outcome: OutcomePass})
+	assert.Empty(t, logLines(buf))
+
+	o.EvaluationFinished(ctx, KindReady, &CheckResponse{StatusCode: 503, Outcome: OutcomeFail})
+	lines := logLines(buf)
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `msg="health state changed"`)
+	assert.Contains(t, lines[0], "kind=ready")
+	assert.Contains(t, lines[0], "statusCode=503")
+
+	o.EvaluationFinished(ctx, KindReady, &CheckResponse{StatusCode: 503, Outcome: OutcomeFail})
+	assert.Empty(t, logLines(buf))
+}
+
+func TestSlogDetailLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	SlogDetailLogger(logger)(context.Background(), &CheckResponse{
+		Status: "Service Unavailable",
+		Checks: map[string]CheckResponseEntry{
+			"db": {Outcome: OutcomeFail, Error: "connection refused"},
+		},
+	})
+
+	assert.Contains(t, buf.String(), "level=DEBUG")
+	assert.Contains(t, buf.String(), "db.outcome=fail")
+	assert.Contains(t, buf.String(), `db.error="connection refused"`)
+}
+
+func TestHealthcheck_WithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	hc := NewHealthcheck(
+		WithLogger(logger),
+		WithCheck("db", CheckerFunc(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})),
+	)
+
+	hc.Health(context.Background())
+
+	assert.Contains(t, buf.String(), `msg="health check state changed"`)
+	assert.Contains(t, buf.String(), `msg="health state changed"`)
+}
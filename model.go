@@ -1,17 +1,62 @@
 package svchealthcheck
 
 const (
-	HealthPath = "/healthz"
-	ReadyPath  = "/readyz"
+	HealthPath  = "/healthz"
+	ReadyPath   = "/readyz"
+	HistoryPath = "/healthz/history"
 )
 
 type CheckResponse struct {
-	StatusCode int                           `json:"-"`
-	Status     string                        `json:"status"`
-	Checks     map[string]CheckResponseEntry `json:"checks"`
+	StatusCode      int                           `json:"-"`
+	Status          string                        `json:"status"`
+	Outcome         Outcome                       `json:"outcome,omitempty"`
+	Cached          bool                          `json:"cached,omitempty"`
+	AbandonedChecks int                           `json:"abandonedChecks,omitempty"`
+	Checks          map[string]CheckResponseEntry `json:"checks,omitempty"`
 }
 
 type CheckResponseEntry struct {
-	Error    string `json:"error,omitempty"`
-	Duration string `json:"duration"`
+	Outcome       Outcome                       `json:"outcome,omitempty"`
+	Error         string                        `json:"error,omitempty"`
+	Duration      string                        `json:"duration,omitempty"`
+	ObservedValue interface{}                   `json:"observedValue,omitempty"`
+	ObservedUnit  string                        `json:"observedUnit,omitempty"`
+	Details       map[string]interface{}        `json:"details,omitempty"`
+	Components    map[string]CheckResponseEntry `json:"components,omitempty"`
+	Kinds         []CheckKind                   `json:"kinds,omitempty"`
+}
+
+// WithDetail returns the response reduced to the given DetailLevel. The response itself is not modified.
+func (r *CheckResponse) WithDetail(level DetailLevel) *CheckResponse {
+	if level <= DetailFull {
+		return r
+	}
+	public := *r
+	if level >= DetailStatusOnly {
+		public.Checks = nil
+		return &public
+	}
+	public.Checks = redactEntries(r.Checks, nil, level)
+	return &public
+}
+
+// newCheckResponseEntry converts a CheckResult, and its components, into a CheckResponseEntry.
+func newCheckResponseEntry(r CheckResult) CheckResponseEntry {
+	entry := CheckResponseEntry{
+		Outcome:       r.EffectiveOutcome(),
+		Error:         errorMessage(r.Err),
+		ObservedValue: r.ObservedValue,
+		ObservedUnit:  r.ObservedUnit,
+		Details:       r.Details,
+	}
+	if r.Duration > 0 {
+		entry.Duration = r.Duration.String()
+	}
+	if len(r.Components) > 0 {
+		entry.Components = make(map[string]CheckResponseEntry, len(r.Components))
+		for name, component := range r.Components {
+			entry.Components[name] = newCheckResponseEntry(component)
+		}
+	}
+	return entry
 }
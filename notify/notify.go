@@ -0,0 +1,93 @@
+// Package notify sends notifications when the outcome of the svchealthcheck.Healthcheck checks changes.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+// ErrUnexpectedStatus is returned when the notification endpoint replies with a non 2xx status code.
+var ErrUnexpectedStatus = errors.New("unexpected status code")
+
+// Event describes a state transition.
+type Event struct {
+	Kind svchealthcheck.CheckKind `json:"kind"`
+	// Check is the name of the check that changed. It is empty when the overall outcome changed.
+	Check    string                 `json:"check,omitempty"`
+	Previous svchealthcheck.Outcome `json:"previous"`
+	Current  svchealthcheck.Outcome `json:"current"`
+	// Error is the error message of the check, only set for check events.
+	Error string    `json:"error,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+// Subject returns a short description of what changed, such as "ready" or "health check database".
+func (e Event) Subject() string {
+	if e.Check == "" {
+		return string(e.Kind)
+	}
+	return fmt.Sprintf("%s check %s", e.Kind, e.Check)
+}
+
+// Notifier sends the notification of an Event.
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// NotifierFunc is a Notifier defined as a function.
+type NotifierFunc func(ctx context.Context, event Event) error
+
+// Notify implements the Notifier interface.
+func (f NotifierFunc) Notify(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// Multi returns a Notifier that sends the notification to all the given notifiers, returning their joined errors.
+func Multi(notifiers ...Notifier) Notifier {
+	return NotifierFunc(func(ctx context.Context, event Event) error {
+		errs := make([]error, 0, len(notifiers))
+		for _, notifier := range notifiers {
+			errs = append(errs, notifier.Notify(ctx, event))
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// postJSON sends the payload, encoded as JSON, to the url.
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+	return nil
+}
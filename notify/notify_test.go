@@ -0,0 +1,99 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+// newServer starts a httptest.Server that replies with the given status code and sends the requests it receives to
+// the returned channel.
+func newServer(t *testing.T, statusCode int) (*httptest.Server, <-chan request) {
+	t.Helper()
+	requests := make(chan request, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{header: r.Header, body: body}
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, requests
+}
+
+func receive(t *testing.T, requests <-chan request) request {
+	t.Helper()
+	select {
+	case r := <-requests:
+		return r
+	case <-time.After(time.Second):
+		require.FailNow(t, "no request received")
+		return request{}
+	}
+}
+
+func decode(t *testing.T, r request) map[string]interface{} {
+	t.Helper()
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(r.body, &payload))
+	return payload
+}
+
+func TestEvent_Subject(t *testing.T) {
+	assert.Equal(t, "ready", Event{Kind: svchealthcheck.KindReady}.Subject())
+	assert.Equal(t, "health check database", Event{Kind: svchealthcheck.KindHealth, Check: "database"}.Subject())
+}
+
+func TestMulti(t *testing.T) {
+	errBoom := errors.New("boom")
+	var calls int
+	notifier := Multi(
+		NotifierFunc(func(ctx context.Context, event Event) error {
+			calls++
+			return errBoom
+		}),
+		NotifierFunc(func(ctx context.Context, event Event) error {
+			calls++
+			return nil
+		}),
+	)
+
+	err := notifier.Notify(context.Background(), Event{})
+	assert.ErrorIs(t, err, errBoom)
+	assert.Equal(t, 2, calls, "all notifiers should be called")
+}
+
+func Test_postJSON(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should post the payload", func(t *testing.T) {
+		srv, requests := newServer(t, http.StatusAccepted)
+		err := postJSON(ctx, nil, srv.URL, map[string]string{"X-Token": "s3cr3t"}, map[string]string{"key": "value"})
+		require.NoError(t, err)
+
+		r := receive(t, requests)
+		assert.Equal(t, "application/json", r.header.Get("Content-Type"))
+		assert.Equal(t, "s3cr3t", r.header.Get("X-Token"))
+		assert.JSONEq(t, `{"key":"value"}`, string(r.body))
+	})
+
+	t.Run("should fail on non 2xx status codes", func(t *testing.T) {
+		srv, _ := newServer(t, http.StatusBadRequest)
+		err := postJSON(ctx, nil, srv.URL, nil, nil)
+		assert.ErrorIs(t, err, ErrUnexpectedStatus)
+	})
+}
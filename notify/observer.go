@@ -0,0 +1,201 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+type Option func(*options)
+
+type options struct {
+	debounce     time.Duration
+	checks       bool
+	timeout      time.Duration
+	redactors    []svchealthcheck.Redactor
+	errorHandler func(event Event, err error)
+}
+
+func defaultOpts() options {
+	return options{
+		timeout: 10 * time.Second,
+	}
+}
+
+// WithDebounce makes the Observer notify a transition only after the new outcome is observed for at least d, so
+// flapping checks do not flood the notifiers. As the outcomes are only observed when the checks are evaluated, the
+// notification is sent by the first evaluation after d.
+func WithDebounce(d time.Duration) Option {
+	return func(o *options) {
+		o.debounce = d
+	}
+}
+
+// WithCheckTransitions makes the Observer notify the transitions of each check, besides the transitions of the overall
+// outcome.
+func WithCheckTransitions() Option {
+	return func(o *options) {
+		o.checks = true
+	}
+}
+
+// WithTimeout sets the timeout of each notification. It defaults to 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithRedactor adds a svchealthcheck.Redactor applied to the error messages of the events. The Observer receives the
+// errors as returned by the checkers, so the redactors given to svchealthcheck.WithRedactor should be given here too. It
+// can be used multiple times, the redactors are applied in order.
+func WithRedactor(redactor svchealthcheck.Redactor) Option {
+	return func(o *options) {
+		o.redactors = append(o.redactors, redactor)
+	}
+}
+
+// WithErrorHandler sets the function called when a notification fails. By default, the errors are ignored.
+func WithErrorHandler(handler func(event Event, err error)) Option {
+	return func(o *options) {
+		o.errorHandler = handler
+	}
+}
+
+// state is the debouncing state of the outcome of the overall result or of a check.
+type state struct {
+	notified svchealthcheck.Outcome
+	pending  svchealthcheck.Outcome
+	since    time.Time
+}
+
+// Observer implements svchealthcheck.Observer, sending the transitions of the outcomes to a Notifier. The
+// notifications are sent in background, so they do not delay the responses, one at a time and in the order of the
+// transitions.
+type Observer struct {
+	notifier Notifier
+	opts     options
+	now      func() time.Time
+
+	mu      sync.Mutex
+	states  map[string]*state
+	queue   []Event
+	sending bool
+}
+
+// NewObserver returns an Observer sending the notifications to the given Notifier. Use Multi to send them to more
+// than one.
+func NewObserver(notifier Notifier, opts ...Option) *Observer {
+	o := defaultOpts()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Observer{
+		notifier: notifier,
+		opts:     o,
+		now:      time.Now,
+		states:   make(map[string]*state),
+	}
+}
+
+// EvaluationStarted implements the svchealthcheck.Observer interface.
+func (o *Observer) EvaluationStarted(ctx context.Context, _ svchealthcheck.CheckKind) context.Context {
+	return ctx
+}
+
+// EvaluationFinished implements the svchealthcheck.Observer interface.
+func (o *Observer) EvaluationFinished(_ context.Context, kind svchealthcheck.CheckKind, response *svchealthcheck.CheckResponse) {
+	o.observe(Event{
+		Kind:    kind,
+		Current: response.Outcome,
+	})
+}
+
+// CheckStarted implements the svchealthcheck.Observer interface.
+func (o *Observer) CheckStarted(ctx context.Context, _ svchealthcheck.CheckKind, _ string) context.Context {
+	return ctx
+}
+
+// CheckFinished implements the svchealthcheck.Observer interface.
+func (o *Observer) CheckFinished(_ context.Context, kind svchealthcheck.CheckKind, name string, result svchealthcheck.CheckResult, _ time.Duration) {
+	if !o.opts.checks {
+		return
+	}
+	event := Event{
+		Kind:    kind,
+		Check:   name,
+		Current: result.EffectiveOutcome(),
+	}
+	if result.Err != nil {
+		event.Error = result.Err.Error()
+		for _, redactor := range o.opts.redactors {
+			event.Error = redactor.Redact(event.Error)
+		}
+	}
+	o.observe(event)
+}
+
+// observe updates the state of the event subject and sends the event if the transition is confirmed. The outcomes
+// start as passing, so a subject that passes from the start is never notified.
+func (o *Observer) observe(event Event) {
+	now := o.now()
+	key := event.Subject()
+
+	o.mu.Lock()
+	s, ok := o.states[key]
+	if !ok {
+		s = &state{notified: svchealthcheck.OutcomePass, pending: svchealthcheck.OutcomePass}
+		o.states[key] = s
+	}
+	if event.Current == s.notified {
+		s.pending = s.notified
+		o.mu.Unlock()
+		return
+	}
+	if event.Current != s.pending {
+		s.pending = event.Current
+		s.since = now
+	}
+	if now.Sub(s.since) < o.opts.debounce {
+		o.mu.Unlock()
+		return
+	}
+	event.Previous = s.notified
+	event.Time = now
+	s.notified = event.Current
+	o.queue = append(o.queue, event)
+	if !o.sending {
+		o.sending = true
+		go o.drain()
+	}
+	o.mu.Unlock()
+}
+
+// drain sends the queued events in order until the queue is empty. Only one drain runs at a time.
+func (o *Observer) drain() {
+	for {
+		o.mu.Lock()
+		if len(o.queue) == 0 {
+			o.sending = false
+			o.mu.Unlock()
+			return
+		}
+		event := o.queue[0]
+		o.queue[0] = Event{}
+		o.queue = o.queue[1:]
+		o.mu.Unlock()
+
+		o.send(event)
+	}
+}
+
+func (o *Observer) send(event Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), o.opts.timeout)
+	defer cancel()
+
+	if err := o.notifier.Notify(ctx, event); err != nil && o.opts.errorHandler != nil {
+		o.opts.errorHandler(event, err)
+	}
+}
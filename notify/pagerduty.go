@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+// PagerDutyEventsURL is the endpoint of the PagerDuty Events API v2.
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyOptions configures the PagerDuty notifier.
+type PagerDutyOptions struct {
+	// Client is the HTTP client used to send the notifications. It defaults to http.DefaultClient.
+	Client *http.Client
+	// URL is the endpoint of the Events API. It defaults to PagerDutyEventsURL.
+	URL string
+	// Source identifies the service in the alerts. It is also part of the dedup key, so each service has its own
+	// alerts. It defaults to the hostname.
+	Source string
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string `json:"summary"`
+	Source        string `json:"source"`
+	Severity      string `json:"severity"`
+	Timestamp     string `json:"timestamp"`
+	Component     string `json:"component,omitempty"`
+	Group         string `json:"group,omitempty"`
+	CustomDetails Event  `json:"custom_details"`
+}
+
+// PagerDuty returns a Notifier that sends the events to the PagerDuty Events API v2 using the given integration
+// routing key. Transitions to a failed outcome trigger an alert, warnings trigger an alert with the warning severity and
+// the other transitions resolve it.
+func PagerDuty(routingKey string, opts PagerDutyOptions) Notifier {
+	url := opts.URL
+	if url == "" {
+		url = PagerDutyEventsURL
+	}
+	source := opts.Source
+	if source == "" {
+		source, _ = os.Hostname()
+	}
+	return NotifierFunc(func(ctx context.Context, event Event) error {
+		pdEvent := pagerDutyEvent{
+			RoutingKey:  routingKey,
+			EventAction: "resolve",
+			DedupKey:    source + "/" + event.Subject(),
+		}
+		if severity := pagerDutySeverity(event.Current); severity != "" {
+			pdEvent.EventAction = "trigger"
+			pdEvent.Payload = &pagerDutyPayload{
+				Summary:       event.Subject() + " is " + string(event.Current),
+				Source:        source,
+				Severity:      severity,
+				Timestamp:     event.Time.Format(time.RFC3339),
+				Component:     event.Check,
+				Group:         string(event.Kind),
+				CustomDetails: event,
+			}
+		}
+		return postJSON(ctx, opts.Client, url, nil, pdEvent)
+	})
+}
+
+// pagerDutySeverity returns the severity of the alert of the outcome, or an empty string if it should not alert.
+func pagerDutySeverity(outcome svchealthcheck.Outcome) string {
+	switch {
+	case outcome.Failed():
+		return "critical"
+	case outcome == svchealthcheck.OutcomeWarn:
+		return "warning"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,66 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func TestPagerDuty(t *testing.T) {
+	ctx := context.Background()
+	srv, requests := newServer(t, http.StatusAccepted)
+	notifier := PagerDuty("routing-key", PagerDutyOptions{URL: srv.URL, Source: "api"})
+
+	t.Run("should trigger an alert when the outcome fails", func(t *testing.T) {
+		err := notifier.Notify(ctx, Event{
+			Kind:     svchealthcheck.KindReady,
+			Check:    "database",
+			Previous: svchealthcheck.OutcomePass,
+			Current:  svchealthcheck.OutcomeFail,
+			Time:     time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+
+		payload := decode(t, receive(t, requests))
+		assert.Equal(t, "routing-key", payload["routing_key"])
+		assert.Equal(t, "trigger", payload["event_action"])
+		assert.Equal(t, "api/ready check database", payload["dedup_key"])
+		require.Contains(t, payload, "payload")
+		details := payload["payload"].(map[string]interface{})
+		assert.Equal(t, "ready check database is fail", details["summary"])
+		assert.Equal(t, "api", details["source"])
+		assert.Equal(t, "critical", details["severity"])
+		assert.Equal(t, "2022-01-01T00:00:00Z", details["timestamp"])
+		assert.Equal(t, "database", details["component"])
+	})
+
+	t.Run("should trigger a warning", func(t *testing.T) {
+		err := notifier.Notify(ctx, Event{Kind: svchealthcheck.KindReady, Current: svchealthcheck.OutcomeWarn})
+		require.NoError(t, err)
+
+		payload := decode(t, receive(t, requests))
+		assert.Equal(t, "trigger", payload["event_action"])
+		assert.Equal(t, "warning", payload["payload"].(map[string]interface{})["severity"])
+	})
+
+	t.Run("should resolve the alert when the outcome passes", func(t *testing.T) {
+		err := notifier.Notify(ctx, Event{
+			Kind:     svchealthcheck.KindReady,
+			Check:    "database",
+			Previous: svchealthcheck.OutcomeFail,
+			Current:  svchealthcheck.OutcomePass,
+		})
+		require.NoError(t, err)
+
+		payload := decode(t, receive(t, requests))
+		assert.Equal(t, "resolve", payload["event_action"])
+		assert.Equal(t, "api/ready check database", payload["dedup_key"])
+		assert.NotContains(t, payload, "payload")
+	})
+}
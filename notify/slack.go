@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+// SlackOptions configures the Slack notifier.
+type SlackOptions struct {
+	// Client is the HTTP client used to send the notifications. It defaults to http.DefaultClient.
+	Client *http.Client
+	// Channel overrides the default channel of the incoming webhook.
+	Channel string
+	// Username overrides the default username of the incoming webhook.
+	Username string
+}
+
+type slackMessage struct {
+	Text     string `json:"text"`
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// Slack returns a Notifier that posts a message to the given Slack incoming webhook url. Any service accepting the
+// same payload, such as Mattermost or Rocket.Chat, can be used as well.
+func Slack(webhookURL string, opts SlackOptions) Notifier {
+	return NotifierFunc(func(ctx context.Context, event Event) error {
+		return postJSON(ctx, opts.Client, webhookURL, nil, slackMessage{
+			Text:     slackText(event),
+			Channel:  opts.Channel,
+			Username: opts.Username,
+		})
+	})
+}
+
+func slackText(event Event) string {
+	icon := ":white_check_mark:"
+	switch {
+	case event.Current.Failed():
+		icon = ":red_circle:"
+	case event.Current == svchealthcheck.OutcomeWarn:
+		icon = ":warning:"
+	}
+	text := fmt.Sprintf("%s *%s* changed from %s to %s", icon, event.Subject(), event.Previous, event.Current)
+	if event.Error != "" {
+		text += fmt.Sprintf(": `%s`", event.Error)
+	}
+	return text
+}
@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func TestSlack(t *testing.T) {
+	srv, requests := newServer(t, http.StatusOK)
+	notifier := Slack(srv.URL, SlackOptions{Channel: "#alerts"})
+
+	err := notifier.Notify(context.Background(), Event{
+		Kind:     svchealthcheck.KindHealth,
+		Check:    "database",
+		Previous: svchealthcheck.OutcomePass,
+		Current:  svchealthcheck.OutcomeFail,
+		Error:    "connection refused",
+	})
+	require.NoError(t, err)
+
+	payload := decode(t, receive(t, requests))
+	assert.Equal(t, "#alerts", payload["channel"])
+	assert.Equal(t, ":red_circle: *health check database* changed from pass to fail: `connection refused`", payload["text"])
+	assert.NotContains(t, payload, "username")
+}
+
+func Test_slackText(t *testing.T) {
+	assert.Equal(t, ":white_check_mark: *ready* changed from fail to pass",
+		slackText(Event{Kind: svchealthcheck.KindReady, Previous: svchealthcheck.OutcomeFail, Current: svchealthcheck.OutcomePass}))
+	assert.Equal(t, ":warning: *ready* changed from pass to warn",
+		slackText(Event{Kind: svchealthcheck.KindReady, Previous: svchealthcheck.OutcomePass, Current: svchealthcheck.OutcomeWarn}))
+}
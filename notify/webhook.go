@@ -0,0 +1,21 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+)
+
+// WebhookOptions configures the Webhook notifier.
+type WebhookOptions struct {
+	// Client is the HTTP client used to send the notifications. It defaults to http.DefaultClient.
+	Client *http.Client
+	// Headers are added to the requests, for example to authenticate them.
+	Headers map[string]string
+}
+
+// Webhook returns a Notifier that posts the Event, encoded as JSON, to the given url.
+func Webhook(url string, opts WebhookOptions) Notifier {
+	return NotifierFunc(func(ctx context.Context, event Event) error {
+		return postJSON(ctx, opts.Client, url, opts.Headers, event)
+	})
+}
@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func TestWebhook(t *testing.T) {
+	srv, requests := newServer(t, http.StatusOK)
+	notifier := Webhook(srv.URL, WebhookOptions{Headers: map[string]string{"Authorization": "Bearer s3cr3t"}})
+
+	err := notifier.Notify(context.Background(), Event{
+		Kind:     svchealthcheck.KindReady,
+		Check:    "database",
+		Previous: svchealthcheck.OutcomePass,
+		Current:  svchealthcheck.OutcomeFail,
+		Error:    "connection refused",
+		Time:     time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	r := receive(t, requests)
+	assert.Equal(t, "Bearer s3cr3t", r.header.Get("Authorization"))
+	assert.JSONEq(t, `{
+		"kind": "ready",
+		"check": "database",
+		"previous": "pass",
+		"current": "fail",
+		"error": "connection refused",
+		"time": "2022-01-01T00:00:00Z"
+	}`, string(r.body))
+}
@@ -0,0 +1,53 @@
+package svchealthcheck
+
+import (
+	"context"
+	"time"
+)
+
+// CheckKind identifies the set of checks being evaluated.
+type CheckKind string
+
+const (
+	KindHealth CheckKind = "health"
+	KindReady  CheckKind = "ready"
+)
+
+// Observer is notified about the evaluations of the checks. The context returned by the Started methods is passed to
+// the matching Finished method and, for CheckStarted, to the Checker itself. It allows observers to attach values to
+// the context, such as tracing spans.
+type Observer interface {
+	EvaluationStarted(ctx context.Context, kind CheckKind) context.Context
+	EvaluationFinished(ctx context.Context, kind CheckKind, response *CheckResponse)
+	CheckStarted(ctx context.Context, kind CheckKind, name string) context.Context
+	CheckFinished(ctx context.Context, kind CheckKind, name string, result CheckResult, duration time.Duration)
+}
+
+// observers notifies a list of Observers in order.
+type observers []Observer
+
+func (o observers) evaluationStarted(ctx context.Context, kind CheckKind) context.Context {
+	for _, observer := range o {
+		ctx = observer.EvaluationStarted(ctx, kind)
+	}
+	return ctx
+}
+
+func (o observers) evaluationFinished(ctx context.Context, kind CheckKind, response *CheckResponse) {
+	for _, observer := range o {
+		observer.EvaluationFinished(ctx, kind, response)
+	}
+}
+
+func (o observers) checkStarted(ctx context.Context, kind CheckKind, name string) context.Context {
+	for _, observer := range o {
+		ctx = observer.CheckStarted(ctx, kind, name)
+	}
+	return ctx
+}
+
+func (o observers) checkFinished(ctx context.Context, kind CheckKind, name string, result CheckResult, duration time.Duration) {
+	for _, observer := range o {
+		observer.CheckFinished(ctx, kind, name, result, duration)
+	}
+}
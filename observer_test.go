@@ -0,0 +1,83 @@
+package svchealthcheck
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type observerCtxKey struct{}
+
+type recordingObserver struct {
+	mu       sync.Mutex
+	events   []string
+	finished *CheckResponse
+	results  map[string]CheckResult
+}
+
+func (o *recordingObserver) record(event string) {
+	o.mu.Lock()
+	o.events = append(o.events, event)
+	o.mu.Unlock()
+}
+
+func (o *recordingObserver) EvaluationStarted(ctx context.Context, kind CheckKind) context.Context {
+	o.record("evaluation started " + string(kind))
+	return context.WithValue(ctx, observerCtxKey{}, "evaluation")
+}
+
+func (o *recordingObserver) EvaluationFinished(ctx context.Context, kind CheckKind, response *CheckResponse) {
+	o.record("evaluation finished " + string(kind) + " " + ctx.Value(observerCtxKey{}).(string))
+	o.finished = response
+}
+
+func (o *recordingObserver) CheckStarted(ctx context.Context, kind CheckKind, name string) context.Context {
+	o.record("check started " + name + " " + ctx.Value(observerCtxKey{}).(string))
+	return context.WithValue(ctx, observerCtxKey{}, "check "+name)
+}
+
+func (o *recordingObserver) CheckFinished(ctx context.Context, kind CheckKind, name string, result CheckResult, duration time.Duration) {
+	o.record("check finished " + name + " " + ctx.Value(observerCtxKey{}).(string))
+	o.mu.Lock()
+	o.results[name] = result
+	o.mu.Unlock()
+}
+
+func TestWithObserver_generateResponse(t *testing.T) {
+	observer := &recordingObserver{results: make(map[string]CheckResult)}
+	wantErr := errors.New("failed")
+
+	hc := NewHealthcheck(
+		WithObserver(observer),
+		WithReadyCheck("check1", CheckerFunc(func(ctx context.Context) error {
+			assert.Equal(t, "check check1", ctx.Value(observerCtxKey{}), "the checker should receive the observer context")
+			return nil
+		})),
+		WithReadyCheck("check2", CheckerFunc(func(ctx context.Context) error {
+			return wantErr
+		})),
+	)
+
+	response := hc.Ready(context.Background())
+
+	require.Len(t, observer.events, 6)
+	assert.Equal(t, "evaluation started ready", observer.events[0])
+	assert.Equal(t, "evaluation finished ready evaluation", observer.events[5])
+	checkEvents := append([]string(nil), observer.events[1:5]...)
+	sort.Strings(checkEvents)
+	assert.Equal(t, []string{
+		"check finished check1 check check1",
+		"check finished check2 check check2",
+		"check started check1 evaluation",
+		"check started check2 evaluation",
+	}, checkEvents)
+	assert.Same(t, response, observer.finished)
+	assert.NoError(t, observer.results["check1"].Err)
+	assert.Equal(t, wantErr, observer.results["check2"].Err)
+}
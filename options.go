@@ -1,6 +1,9 @@
 package svchealthcheck
 
 import (
+	"log/slog"
+	"net/http"
+	"os"
 	"time"
 
 	srvfiber "github.com/jamillosantos/server-fiber"
@@ -9,19 +12,51 @@ import (
 type Option func(*options)
 
 type options struct {
-	bindAddress    string
-	initializer    srvfiber.Initializer
-	timeout        time.Duration
-	healthCheckers map[string]Checker
-	readyCheckers  map[string]Checker
+	bindAddress     string
+	initializer     srvfiber.Initializer
+	timeout         time.Duration
+	healthCheckers  map[string]Checker
+	readyCheckers   map[string]Checker
+	statusCodes     map[Outcome]int
+	panicStatusCode int
+	statusPolicy    StatusPolicy
+	redactors       []Redactor
+	publicDetail    DetailLevel
+	detailLogger    DetailLogger
+	maxConcurrent   int
+	ratePerSecond   float64
+	rateBurst       int
+	limitPolicy     LimitPolicy
+	maxStaleness    time.Duration
+	maxParallelism  int
+	observers       observers
+	logger          *slog.Logger
+	slowThreshold   time.Duration
+	logInterval     time.Duration
+	historySize     int
+	socketMode      os.FileMode
+	readyWithHealth bool
+	tags            map[string][]string
 }
 
 func defaultOpts() options {
 	return options{
 		bindAddress:    "localhost:8082",
 		timeout:        time.Second * 15,
+		logInterval:    time.Minute,
+		maxStaleness:   time.Minute,
+		historySize:    100,
+		socketMode:     0o660,
 		healthCheckers: make(map[string]Checker),
 		readyCheckers:  make(map[string]Checker),
+		tags:           make(map[string][]string),
+		statusCodes: map[Outcome]int{
+			OutcomePass:    http.StatusOK,
+			OutcomeSkip:    http.StatusOK,
+			OutcomeWarn:    http.StatusOK,
+			OutcomeUnknown: http.StatusServiceUnavailable,
+			OutcomeFail:    http.StatusServiceUnavailable,
+		},
 	}
 }
 
@@ -29,6 +64,8 @@ func (o *options) GetBindAddress() string {
 	return o.bindAddress
 }
 
+// WithBindAddress sets the address served by Healthcheck.Listen. Addresses prefixed by UnixScheme, such as
+// unix:///var/run/health.sock, are served on a Unix domain socket.
 func WithBindAddress(bindAddress string) Option {
 	return func(o *options) {
 		o.bindAddress = bindAddress
@@ -58,3 +95,153 @@ func WithReadyCheck(name string, checker Checker) Option {
 		o.readyCheckers[name] = checker
 	}
 }
+
+// WithTags adds tags to the checks registered with the given name, in both sets. Healthcheck.CheckTag evaluates the
+// checks with a tag. It can be used multiple times.
+func WithTags(name string, tags ...string) Option {
+	return func(o *options) {
+		o.tags[name] = append(o.tags[name], tags...)
+	}
+}
+
+// WithOutcomeStatusCode sets the HTTP status code of the responses whose worst outcome is the given one. It has no
+// effect when WithStatusPolicy is used.
+func WithOutcomeStatusCode(outcome Outcome, code int) Option {
+	return func(o *options) {
+		o.statusCodes[outcome] = code
+	}
+}
+
+// WithPanicStatusCode sets the HTTP status code of the responses where any check panicked. It has no effect when
+// WithStatusPolicy is used.
+func WithPanicStatusCode(code int) Option {
+	return func(o *options) {
+		o.panicStatusCode = code
+	}
+}
+
+// WithStatusPolicy replaces the default OutcomeStatusPolicy.
+func WithStatusPolicy(policy StatusPolicy) Option {
+	return func(o *options) {
+		o.statusPolicy = policy
+	}
+}
+
+// WithRedactor adds a Redactor applied to the error messages of the responses. It can be used multiple times, the
+// redactors are applied in order.
+func WithRedactor(redactor Redactor) Option {
+	return func(o *options) {
+		o.redactors = append(o.redactors, redactor)
+	}
+}
+
+// WithPublicDetail sets the detail level of the responses for requests that were not marked with
+// ContextWithAuthenticated. It defaults to DetailFull.
+func WithPublicDetail(level DetailLevel) Option {
+	return func(o *options) {
+		o.publicDetail = level
+	}
+}
+
+// WithDetailLogger sets the DetailLogger that receives the full responses when their public detail is reduced.
+func WithDetailLogger(logger DetailLogger) Option {
+	return func(o *options) {
+		o.detailLogger = logger
+	}
+}
+
+// WithMaxConcurrentEvaluations limits how many evaluations of the checks, for each of Health and Ready, can run at the
+// same time. The requests above the limit are handled according to WithLimitPolicy.
+func WithMaxConcurrentEvaluations(max int) Option {
+	return func(o *options) {
+		o.maxConcurrent = max
+	}
+}
+
+// WithRateLimit limits how many evaluations of the checks, for each of Health and Ready, can start per second, allowing
+// bursts of up to burst evaluations. The requests above the limit are handled according to WithLimitPolicy.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(o *options) {
+		o.ratePerSecond = perSecond
+		o.rateBurst = burst
+	}
+}
+
+// WithLimitPolicy sets how the requests that exceed the limits are handled. It defaults to LimitServeLast.
+func WithLimitPolicy(policy LimitPolicy) Option {
+	return func(o *options) {
+		o.limitPolicy = policy
+	}
+}
+
+// WithMaxStaleness sets how old the last response can be to be served by LimitServeLast. Older responses are not served
+// and the requests are rejected instead. It defaults to 1 minute, and a zero duration serves the last response
+// regardless of its age.
+func WithMaxStaleness(d time.Duration) Option {
+	return func(o *options) {
+		o.maxStaleness = d
+	}
+}
+
+// WithMaxParallelism limits how many checks of a single evaluation run at the same time. By default, all checks run at
+// the same time.
+func WithMaxParallelism(max int) Option {
+	return func(o *options) {
+		o.maxParallelism = max
+	}
+}
+
+// WithObserver adds an Observer notified about the evaluations of the checks. It can be used multiple times.
+func WithObserver(observer Observer) Option {
+	return func(o *options) {
+		o.observers = append(o.observers, observer)
+	}
+}
+
+// WithLogger enables logging of state transitions, panics and slow checks using the given logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithSlowCheckThreshold makes the logger report checks that take longer than threshold. It only has effect when
+// WithLogger is used.
+func WithSlowCheckThreshold(threshold time.Duration) Option {
+	return func(o *options) {
+		o.slowThreshold = threshold
+	}
+}
+
+// WithLogInterval sets the minimum interval between repeated logs about the same check, so a persistently failing
+// check does not log on every probe. It defaults to one minute and only has effect when WithLogger is used.
+func WithLogInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.logInterval = interval
+	}
+}
+
+// WithHistorySize sets the number of recent results kept for each check, see Healthcheck.History. It defaults to 100
+// and a zero size disables the history.
+func WithHistorySize(size int) Option {
+	return func(o *options) {
+		o.historySize = size
+	}
+}
+
+// WithSocketMode sets the permissions of the Unix domain socket created by Healthcheck.Listen. It defaults to 0660,
+// so only the owner and the group of the process can connect.
+func WithSocketMode(mode os.FileMode) Option {
+	return func(o *options) {
+		o.socketMode = mode
+	}
+}
+
+// WithReadyIncludesHealth makes Ready evaluate the health checks besides the ready checks, so a service is never ready
+// while it is not healthy. A name registered in both sets runs once per request, with the checker given to
+// WithReadyCheck, and the entries of the response list the sets they belong to.
+func WithReadyIncludesHealth() Option {
+	return func(o *options) {
+		o.readyWithHealth = true
+	}
+}
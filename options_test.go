@@ -3,6 +3,10 @@ package svchealthcheck
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
 	"testing"
 	"time"
 
@@ -53,3 +57,125 @@ func Test_options_GetBindAddress(t *testing.T) {
 	gotBindAddr := opts.GetBindAddress()
 	assert.Equal(t, wantBindAddr, gotBindAddr)
 }
+
+func TestWithOutcomeStatusCode(t *testing.T) {
+	opts := defaultOpts()
+	WithOutcomeStatusCode(OutcomeWarn, http.StatusTooManyRequests)(&opts)
+	assert.Equal(t, http.StatusTooManyRequests, opts.statusCodes[OutcomeWarn])
+	assert.Equal(t, http.StatusServiceUnavailable, opts.statusCodes[OutcomeFail])
+}
+
+func TestWithPanicStatusCode(t *testing.T) {
+	var opts options
+	WithPanicStatusCode(http.StatusServiceUnavailable)(&opts)
+	assert.Equal(t, http.StatusServiceUnavailable, opts.panicStatusCode)
+}
+
+func TestWithStatusPolicy(t *testing.T) {
+	var opts options
+	wantPolicy := &OutcomeStatusPolicy{}
+	WithStatusPolicy(wantPolicy)(&opts)
+	assert.Same(t, wantPolicy, opts.statusPolicy)
+}
+
+func TestWithRedactor(t *testing.T) {
+	var opts options
+	WithRedactor(CredentialsRedactor())(&opts)
+	WithRedactor(SecretRedactor("secret"))(&opts)
+	assert.Len(t, opts.redactors, 2)
+}
+
+func TestWithPublicDetail(t *testing.T) {
+	var opts options
+	WithPublicDetail(DetailStatusOnly)(&opts)
+	assert.Equal(t, DetailStatusOnly, opts.publicDetail)
+}
+
+func TestWithDetailLogger(t *testing.T) {
+	var opts options
+	WithDetailLogger(func(ctx context.Context, response *CheckResponse) {})(&opts)
+	assert.NotNil(t, opts.detailLogger)
+}
+
+func TestWithMaxConcurrentEvaluations(t *testing.T) {
+	var opts options
+	WithMaxConcurrentEvaluations(3)(&opts)
+	assert.Equal(t, 3, opts.maxConcurrent)
+}
+
+func TestWithRateLimit(t *testing.T) {
+	var opts options
+	WithRateLimit(2.5, 5)(&opts)
+	assert.Equal(t, 2.5, opts.ratePerSecond)
+	assert.Equal(t, 5, opts.rateBurst)
+}
+
+func TestWithLimitPolicy(t *testing.T) {
+	var opts options
+	WithLimitPolicy(LimitReject)(&opts)
+	assert.Equal(t, LimitReject, opts.limitPolicy)
+}
+
+func TestWithMaxStaleness(t *testing.T) {
+	var opts options
+	WithMaxStaleness(time.Second * 30)(&opts)
+	assert.Equal(t, time.Second*30, opts.maxStaleness)
+}
+
+func TestWithMaxParallelism(t *testing.T) {
+	var opts options
+	WithMaxParallelism(4)(&opts)
+	assert.Equal(t, 4, opts.maxParallelism)
+}
+
+func TestWithObserver(t *testing.T) {
+	var opts options
+	WithObserver(&recordingObserver{})(&opts)
+	WithObserver(&recordingObserver{})(&opts)
+	assert.Len(t, opts.observers, 2)
+}
+
+func TestWithLogger(t *testing.T) {
+	var opts options
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	WithLogger(logger)(&opts)
+	assert.Same(t, logger, opts.logger)
+}
+
+func TestWithSlowCheckThreshold(t *testing.T) {
+	var opts options
+	WithSlowCheckThreshold(time.Second)(&opts)
+	assert.Equal(t, time.Second, opts.slowThreshold)
+}
+
+func TestWithLogInterval(t *testing.T) {
+	var opts options
+	WithLogInterval(time.Second)(&opts)
+	assert.Equal(t, time.Second, opts.logInterval)
+}
+
+func TestWithHistorySize(t *testing.T) {
+	var opts options
+	WithHistorySize(10)(&opts)
+	assert.Equal(t, 10, opts.historySize)
+}
+
+func TestWithSocketMode(t *testing.T) {
+	opts := defaultOpts()
+	assert.Equal(t, os.FileMode(0o660), opts.socketMode)
+	WithSocketMode(0o600)(&opts)
+	assert.Equal(t, os.FileMode(0o600), opts.socketMode)
+}
+
+func TestWithTags(t *testing.T) {
+	opts := defaultOpts()
+	WithTags("database", "storage")(&opts)
+	WithTags("database", "critical")(&opts)
+	assert.Equal(t, []string{"storage", "critical"}, opts.tags["database"])
+}
+
+func TestWithReadyIncludesHealth(t *testing.T) {
+	var opts options
+	WithReadyIncludesHealth()(&opts)
+	assert.True(t, opts.readyWithHealth)
+}
@@ -0,0 +1,74 @@
+package svchealthcheck
+
+import (
+	"errors"
+)
+
+var (
+	// ErrWarn can be wrapped by the error returned by a Checker to report a warning instead of a failure.
+	ErrWarn = errors.New("warning")
+	// ErrSkipped can be wrapped by the error returned by a Checker to report that the check was not performed.
+	ErrSkipped = errors.New("skipped")
+	// ErrUnknown can be wrapped by the error returned by a Checker to report that the health could not be determined.
+	ErrUnknown = errors.New("unknown")
+)
+
+// Outcome is the result of a check.
+type Outcome string
+
+const (
+	OutcomePass    Outcome = "pass"
+	OutcomeSkip    Outcome = "skip"
+	OutcomeWarn    Outcome = "warn"
+	OutcomeUnknown Outcome = "unknown"
+	OutcomeFail    Outcome = "fail"
+)
+
+// severity returns how bad the outcome is, the worst outcome of a response defines its status.
+func (o Outcome) severity() int {
+	switch o {
+	case OutcomePass:
+		return 0
+	case OutcomeSkip:
+		return 1
+	case OutcomeWarn:
+		return 2
+	case OutcomeUnknown:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// Failed returns true if the outcome means the dependency should not be considered healthy.
+func (o Outcome) Failed() bool {
+	return o == OutcomeFail || o == OutcomeUnknown
+}
+
+// OutcomeFromError returns the Outcome represented by the given error: nil passes, errors wrapping ErrSkipped, ErrWarn
+// or ErrUnknown are recognised through errors.Is and any other error fails.
+func OutcomeFromError(err error) Outcome {
+	switch {
+	case err == nil:
+		return OutcomePass
+	case errors.Is(err, ErrSkipped):
+		return OutcomeSkip
+	case errors.Is(err, ErrWarn):
+		return OutcomeWarn
+	case errors.Is(err, ErrUnknown):
+		return OutcomeUnknown
+	default:
+		return OutcomeFail
+	}
+}
+
+// WorstOutcome returns the most severe of the given outcomes. It returns OutcomePass if no outcome is given.
+func WorstOutcome(outcomes ...Outcome) Outcome {
+	worst := OutcomePass
+	for _, o := range outcomes {
+		if o.severity() > worst.severity() {
+			worst = o
+		}
+	}
+	return worst
+}
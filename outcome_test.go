@@ -0,0 +1,34 @@
+package svchealthcheck
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutcomeFromError(t *testing.T) {
+	assert.Equal(t, OutcomePass, OutcomeFromError(nil))
+	assert.Equal(t, OutcomeSkip, OutcomeFromError(fmt.Errorf("%w: feature disabled", ErrSkipped)))
+	assert.Equal(t, OutcomeWarn, OutcomeFromError(fmt.Errorf("%w: disk almost full", ErrWarn)))
+	assert.Equal(t, OutcomeUnknown, OutcomeFromError(fmt.Errorf("%w: no data", ErrUnknown)))
+	assert.Equal(t, OutcomeFail, OutcomeFromError(errors.New("connection refused")))
+}
+
+func TestWorstOutcome(t *testing.T) {
+	assert.Equal(t, OutcomePass, WorstOutcome())
+	assert.Equal(t, OutcomePass, WorstOutcome(OutcomePass, OutcomePass))
+	assert.Equal(t, OutcomeSkip, WorstOutcome(OutcomePass, OutcomeSkip))
+	assert.Equal(t, OutcomeWarn, WorstOutcome(OutcomeSkip, OutcomeWarn, OutcomePass))
+	assert.Equal(t, OutcomeUnknown, WorstOutcome(OutcomeWarn, OutcomeUnknown))
+	assert.Equal(t, OutcomeFail, WorstOutcome(OutcomeFail, OutcomeUnknown, OutcomeWarn))
+}
+
+func TestOutcome_Failed(t *testing.T) {
+	assert.False(t, OutcomePass.Failed())
+	assert.False(t, OutcomeSkip.Failed())
+	assert.False(t, OutcomeWarn.Failed())
+	assert.True(t, OutcomeUnknown.Failed())
+	assert.True(t, OutcomeFail.Failed())
+}
@@ -0,0 +1,61 @@
+package svchealthcheck
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error reported for a Checker that panicked. It wraps ErrCheckerPanic and, when the recovered value
+// is an error, that error too.
+//
+// The stack is only meant for observers and logs: the message returned by Error does not include it, so it is never
+// part of the responses.
+type PanicError struct {
+	// Value is the value recovered from the panic.
+	Value interface{}
+	// Stack is the stack trace of the goroutine that panicked, as returned by debug.Stack.
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	r := e.Value
+	if err, ok := r.(error); ok {
+		r = err.Error()
+	}
+	return fmt.Sprintf("%s: %v", ErrCheckerPanic, r)
+}
+
+// Unwrap returns ErrCheckerPanic and, when the recovered value is an error, the recovered error.
+func (e *PanicError) Unwrap() []error {
+	if err, ok := e.Value.(error); ok {
+		return []error{ErrCheckerPanic, err}
+	}
+	return []error{ErrCheckerPanic}
+}
+
+// NewPanicError returns the PanicError for the value recovered from a panic, capturing the stack of the current
+// goroutine. It must be called from the deferred function that recovered the panic, so checkers running other checkers
+// can report their panics as the Healthcheck does.
+func NewPanicError(r interface{}) error {
+	return &PanicError{
+		Value: r,
+		Stack: debug.Stack(),
+	}
+}
+
+// IsPanic returns true if the error was produced by a panic recovered from a Checker.
+func IsPanic(err error) bool {
+	return errors.Is(err, ErrCheckerPanic)
+}
+
+// PanicStack returns the stack trace of the panic that produced the error, or nil if the error was not produced by a
+// panic.
+func PanicStack(err error) []byte {
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		return panicErr.Stack
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package svchealthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanicError(t *testing.T) {
+	t.Run("should wrap ErrCheckerPanic", func(t *testing.T) {
+		err := NewPanicError("boom")
+		assert.ErrorIs(t, err, ErrCheckerPanic)
+		assert.Equal(t, "checker panicked: boom", err.Error())
+	})
+
+	t.Run("should wrap the recovered error", func(t *testing.T) {
+		recovered := errors.New("boom")
+		err := NewPanicError(recovered)
+		assert.ErrorIs(t, err, ErrCheckerPanic)
+		assert.ErrorIs(t, err, recovered)
+	})
+
+	t.Run("should keep the recovered value and the stack", func(t *testing.T) {
+		err := NewPanicError(42)
+		var panicErr *PanicError
+		require.ErrorAs(t, err, &panicErr)
+		assert.Equal(t, 42, panicErr.Value)
+		assert.Contains(t, string(panicErr.Stack), "goroutine")
+	})
+}
+
+func TestIsPanic(t *testing.T) {
+	assert.True(t, IsPanic(NewPanicError("boom")))
+	assert.True(t, IsPanic(fmt.Errorf("wrapped: %w", NewPanicError("boom"))))
+	assert.False(t, IsPanic(errors.New("checker panicked: boom")))
+	assert.False(t, IsPanic(nil))
+}
+
+func TestPanicStack(t *testing.T) {
+	assert.NotEmpty(t, PanicStack(NewPanicError("boom")))
+	assert.Nil(t, PanicStack(errors.New("boom")))
+	assert.Nil(t, PanicStack(nil))
+}
+
+func TestHealthcheck_panicStackNotSerialized(t *testing.T) {
+	hc := NewHealthcheck(WithCheck("check", CheckerFunc(func(ctx context.Context) error {
+		panic("boom")
+	})))
+
+	response := hc.Health(ContextWithAuthenticated(context.Background()))
+
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "checker panicked: boom")
+	assert.NotContains(t, string(data), "goroutine")
+}
@@ -0,0 +1,35 @@
+package probe
+
+import (
+	"net/http"
+)
+
+type Option func(*options)
+
+type options struct {
+	client  *http.Client
+	headers http.Header
+}
+
+func defaultOpts() options {
+	return options{
+		client:  http.DefaultClient,
+		headers: make(http.Header),
+	}
+}
+
+// WithHTTPClient sets the client of the requests to HTTP addresses. It defaults to http.DefaultClient. Addresses
+// prefixed by UnixScheme always use a client connected to the socket.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithHeader adds a header to the requests, such as the credentials required by endpoints that only return the
+// checks to authenticated requests. It can be used multiple times.
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		o.headers.Add(key, value)
+	}
+}
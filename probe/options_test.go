@@ -0,0 +1,23 @@
+package probe
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithHTTPClient(t *testing.T) {
+	client := &http.Client{}
+	opts := defaultOpts()
+	WithHTTPClient(client)(&opts)
+	assert.Same(t, client, opts.client)
+}
+
+func TestWithHeader(t *testing.T) {
+	opts := defaultOpts()
+	WithHeader("Authorization", "Bearer token")(&opts)
+	WithHeader("X-Tenant", "a")(&opts)
+	assert.Equal(t, "Bearer token", opts.headers.Get("Authorization"))
+	assert.Equal(t, "a", opts.headers.Get("X-Tenant"))
+}
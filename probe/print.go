@@ -0,0 +1,75 @@
+package probe
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+// Print writes the response in a human-readable form: a summary line and, when verbose, a line for each check and
+// its components.
+func Print(w io.Writer, kind svchealthcheck.CheckKind, r *svchealthcheck.CheckResponse, verbose bool) error {
+	summary := fmt.Sprintf("%s: %s (%d", kind, r.Status, r.StatusCode)
+	if r.Outcome != "" {
+		summary += ", " + string(r.Outcome)
+	}
+	if r.Cached {
+		summary += ", cached"
+	}
+	if _, err := fmt.Fprintln(w, summary+")"); err != nil {
+		return err
+	}
+	if !verbose {
+		return nil
+	}
+	return printEntries(w, r.Checks, 1)
+}
+
+func printEntries(w io.Writer, entries map[string]svchealthcheck.CheckResponseEntry, depth int) error {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	indent := strings.Repeat("  ", depth)
+	for _, name := range names {
+		entry := entries[name]
+		line := fmt.Sprintf("%s%s %s", indent, outcomeSymbol(entry.Outcome), name)
+		if entry.Outcome != "" {
+			line += " " + string(entry.Outcome)
+		}
+		if entry.Duration != "" {
+			line += " " + entry.Duration
+		}
+		if entry.ObservedValue != nil {
+			line += fmt.Sprintf(" [%v%s]", entry.ObservedValue, entry.ObservedUnit)
+		}
+		if entry.Error != "" {
+			line += ": " + entry.Error
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		if err := printEntries(w, entry.Components, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func outcomeSymbol(outcome svchealthcheck.Outcome) string {
+	switch {
+	case outcome.Failed():
+		return "[x]"
+	case outcome == svchealthcheck.OutcomeWarn:
+		return "[!]"
+	case outcome == svchealthcheck.OutcomeSkip:
+		return "[-]"
+	default:
+		return "[v]"
+	}
+}
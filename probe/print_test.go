@@ -0,0 +1,51 @@
+package probe
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func TestPrint(t *testing.T) {
+	r := &svchealthcheck.CheckResponse{
+		StatusCode: 503,
+		Status:     "Service Unavailable",
+		Outcome:    svchealthcheck.OutcomeFail,
+		Checks: map[string]svchealthcheck.CheckResponseEntry{
+			"database": {Outcome: svchealthcheck.OutcomeFail, Duration: "12ms", Error: "connection refused"},
+			"brokers": {
+				Outcome: svchealthcheck.OutcomeWarn,
+				Components: map[string]svchealthcheck.CheckResponseEntry{
+					"0": {Outcome: svchealthcheck.OutcomePass, ObservedValue: 3, ObservedUnit: "ms"},
+					"1": {Outcome: svchealthcheck.OutcomeSkip},
+				},
+			},
+		},
+	}
+
+	t.Run("should print only the summary", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Print(&buf, svchealthcheck.KindReady, r, false))
+		assert.Equal(t, "ready: Service Unavailable (503, fail)\n", buf.String())
+	})
+
+	t.Run("should print the checks when verbose", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Print(&buf, svchealthcheck.KindReady, r, true))
+		assert.Equal(t, "ready: Service Unavailable (503, fail)\n"+
+			"  [!] brokers warn\n"+
+			"    [v] 0 pass [3ms]\n"+
+			"    [-] 1 skip\n"+
+			"  [x] database fail 12ms: connection refused\n", buf.String())
+	})
+
+	t.Run("should mark cached responses", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Print(&buf, svchealthcheck.KindHealth, &svchealthcheck.CheckResponse{StatusCode: 200, Status: "OK", Cached: true}, false))
+		assert.Equal(t, "health: OK (200, cached)\n", buf.String())
+	})
+}
@@ -0,0 +1,86 @@
+// Package probe queries the health endpoints of a service, over HTTP or a Unix socket. It backs the healthprobe
+// command, used by the exec-based probes of images without curl.
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+// UnixScheme is the scheme of the addresses of Unix sockets, such as unix:///var/run/service.sock.
+const UnixScheme = svchealthcheck.UnixScheme
+
+// ErrInvalidResponse is returned when the endpoint does not reply with a CheckResponse.
+var ErrInvalidResponse = errors.New("invalid health response")
+
+// Probe requests the endpoint of the given kind and returns its CheckResponse, with the StatusCode of the reply.
+//
+// The address is the base URL of the service, such as http://localhost:8082, or the path of a Unix socket prefixed
+// by UnixScheme. Addresses without a scheme are handled as HTTP.
+func Probe(ctx context.Context, address string, kind svchealthcheck.CheckKind, opts ...Option) (*svchealthcheck.CheckResponse, error) {
+	o := defaultOpts()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	client := o.client
+	baseURL := address
+	switch {
+	case strings.HasPrefix(address, UnixScheme):
+		client = unixClient(strings.TrimPrefix(address, UnixScheme))
+		baseURL = "http://unix"
+	case !strings.Contains(address, "://"):
+		baseURL = "http://" + address
+	}
+
+	path := svchealthcheck.HealthPath
+	if kind == svchealthcheck.KindReady {
+		path = svchealthcheck.ReadyPath
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range o.headers {
+		req.Header[key] = values
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var r svchealthcheck.CheckResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, fmt.Errorf("%w: %d: %s", ErrInvalidResponse, resp.StatusCode, err)
+	}
+	r.StatusCode = resp.StatusCode
+	return &r, nil
+}
+
+// Healthy returns true if the response has a 2xx status code.
+func Healthy(r *svchealthcheck.CheckResponse) bool {
+	return r.StatusCode >= 200 && r.StatusCode <= 299
+}
+
+// unixClient returns a http.Client that connects to the Unix socket at the given path. Each Probe creates its own
+// client, so keep-alives are disabled to close the connection with the response instead of leaving it idle.
+func unixClient(path string) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DisableKeepAlives: true,
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+}
@@ -0,0 +1,138 @@
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func healthHandler() http.Handler {
+	mux := http.NewServeMux()
+	write := func(w http.ResponseWriter, r *svchealthcheck.CheckResponse) {
+		w.WriteHeader(r.StatusCode)
+		_ = json.NewEncoder(w).Encode(r)
+	}
+	mux.HandleFunc(svchealthcheck.HealthPath, func(w http.ResponseWriter, _ *http.Request) {
+		write(w, &svchealthcheck.CheckResponse{StatusCode: http.StatusOK, Status: "OK", Outcome: svchealthcheck.OutcomePass})
+	})
+	mux.HandleFunc(svchealthcheck.ReadyPath, func(w http.ResponseWriter, _ *http.Request) {
+		write(w, &svchealthcheck.CheckResponse{
+			StatusCode: http.StatusServiceUnavailable,
+			Status:     "Service Unavailable",
+			Outcome:    svchealthcheck.OutcomeFail,
+			Checks: map[string]svchealthcheck.CheckResponseEntry{
+				"database": {Outcome: svchealthcheck.OutcomeFail, Error: "connection refused"},
+			},
+		})
+	})
+	return mux
+}
+
+func TestProbe(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(healthHandler())
+	t.Cleanup(srv.Close)
+
+	t.Run("should return the health response", func(t *testing.T) {
+		r, err := Probe(ctx, srv.URL, svchealthcheck.KindHealth)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, r.StatusCode)
+		assert.Equal(t, svchealthcheck.OutcomePass, r.Outcome)
+		assert.True(t, Healthy(r))
+	})
+
+	t.Run("should return the ready response", func(t *testing.T) {
+		r, err := Probe(ctx, srv.URL+"/", svchealthcheck.KindReady)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, r.StatusCode)
+		assert.Equal(t, "connection refused", r.Checks["database"].Error)
+		assert.False(t, Healthy(r))
+	})
+
+	t.Run("should handle addresses without a scheme as http", func(t *testing.T) {
+		r, err := Probe(ctx, strings.TrimPrefix(srv.URL, "http://"), svchealthcheck.KindHealth)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, r.StatusCode)
+	})
+
+	t.Run("should fail when the response is not a CheckResponse", func(t *testing.T) {
+		notFound := httptest.NewServer(http.NotFoundHandler())
+		t.Cleanup(notFound.Close)
+
+		_, err := Probe(ctx, notFound.URL, svchealthcheck.KindHealth)
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+	})
+
+	t.Run("should fail when the service is not reachable", func(t *testing.T) {
+		_, err := Probe(ctx, UnixScheme+filepath.Join(t.TempDir(), "missing.sock"), svchealthcheck.KindHealth)
+		assert.Error(t, err)
+	})
+}
+
+func TestProbe_options(t *testing.T) {
+	var authorization string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		healthHandler().ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	var requests int
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+
+	r, err := Probe(context.Background(), srv.URL, svchealthcheck.KindHealth, WithHTTPClient(client), WithHeader("Authorization", "Bearer token"))
+	require.NoError(t, err)
+	assert.True(t, Healthy(r))
+	assert.Equal(t, "Bearer token", authorization)
+	assert.Equal(t, 1, requests)
+}
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestProbe_unixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "health.sock")
+	lis, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	srv := &http.Server{Handler: healthHandler()}
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+
+	r, err := Probe(context.Background(), UnixScheme+path, svchealthcheck.KindReady)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, r.StatusCode)
+	assert.Equal(t, svchealthcheck.OutcomeFail, r.Outcome)
+
+	t.Run("should not leave connections open", func(t *testing.T) {
+		before := runtime.NumGoroutine()
+		for i := 0; i < 50; i++ {
+			_, err := Probe(context.Background(), UnixScheme+path, svchealthcheck.KindHealth)
+			require.NoError(t, err)
+		}
+		assert.Eventually(t, func() bool {
+			return runtime.NumGoroutine() <= before+5
+		}, time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,113 @@
+package push
+
+import (
+	"net/http"
+	"time"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+type Option func(*options)
+
+type options struct {
+	failURL        string
+	kind           svchealthcheck.CheckKind
+	interval       time.Duration
+	timeout        time.Duration
+	retries        int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	jitter         float64
+	fullDetail     bool
+	client         *http.Client
+	errorHandler   func(err error)
+}
+
+func defaultOpts() options {
+	return options{
+		kind:           svchealthcheck.KindHealth,
+		interval:       time.Minute,
+		timeout:        10 * time.Second,
+		retries:        3,
+		initialBackoff: time.Second,
+		maxBackoff:     30 * time.Second,
+		jitter:         0.2,
+		client:         http.DefaultClient,
+	}
+}
+
+// WithFailURL sets the URL that receives the pushes when the checks fail, such as the /fail endpoint of
+// healthchecks.io. When it is not set, failures are not pushed and the monitor alerts when the pushes stop.
+func WithFailURL(url string) Option {
+	return func(o *options) {
+		o.failURL = url
+	}
+}
+
+// WithReady pushes the result of the ready checks instead of the health checks.
+func WithReady() Option {
+	return func(o *options) {
+		o.kind = svchealthcheck.KindReady
+	}
+}
+
+// WithInterval sets the interval between the pushes of Run. It defaults to one minute.
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// WithTimeout sets the timeout of each push request. It defaults to 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithRetries sets how many times a failed push is retried. It defaults to 3.
+func WithRetries(retries int) Option {
+	return func(o *options) {
+		o.retries = retries
+	}
+}
+
+// WithBackoff sets the delay before the first retry, which doubles on each retry up to max. It defaults to one second
+// up to 30 seconds.
+func WithBackoff(initial, max time.Duration) Option {
+	return func(o *options) {
+		o.initialBackoff = initial
+		o.maxBackoff = max
+	}
+}
+
+// WithJitter sets the fraction (0-1) of the intervals and backoff delays that is randomized, so many instances do not
+// push at the same time. It defaults to 0.2.
+func WithJitter(jitter float64) Option {
+	return func(o *options) {
+		o.jitter = jitter
+	}
+}
+
+// WithFullDetail pushes the full response, as authenticated requests receive it. By default, the pushed response has
+// the public detail level set by svchealthcheck.WithPublicDetail.
+func WithFullDetail() Option {
+	return func(o *options) {
+		o.fullDetail = true
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to push. It defaults to http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithErrorHandler sets the function called when a push fails after all the retries. By default, the errors are
+// ignored.
+func WithErrorHandler(handler func(err error)) Option {
+	return func(o *options) {
+		o.errorHandler = handler
+	}
+}
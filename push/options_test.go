@@ -0,0 +1,51 @@
+package push
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+func TestDefaultOpts(t *testing.T) {
+	opts := defaultOpts()
+	assert.Equal(t, svchealthcheck.KindHealth, opts.kind)
+	assert.Equal(t, time.Minute, opts.interval)
+	assert.Equal(t, 3, opts.retries)
+	assert.Same(t, http.DefaultClient, opts.client)
+}
+
+func TestWithFailURL(t *testing.T) {
+	opts := defaultOpts()
+	WithFailURL("https://hc-ping.com/uuid/fail")(&opts)
+	assert.Equal(t, "https://hc-ping.com/uuid/fail", opts.failURL)
+}
+
+func TestWithInterval(t *testing.T) {
+	opts := defaultOpts()
+	WithInterval(time.Second)(&opts)
+	assert.Equal(t, time.Second, opts.interval)
+}
+
+func TestWithTimeout(t *testing.T) {
+	opts := defaultOpts()
+	WithTimeout(time.Second)(&opts)
+	assert.Equal(t, time.Second, opts.timeout)
+}
+
+func TestWithBackoff(t *testing.T) {
+	opts := defaultOpts()
+	WithBackoff(time.Millisecond, time.Second)(&opts)
+	assert.Equal(t, time.Millisecond, opts.initialBackoff)
+	assert.Equal(t, time.Second, opts.maxBackoff)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	opts := defaultOpts()
+	client := &http.Client{}
+	WithHTTPClient(client)(&opts)
+	assert.Same(t, client, opts.client)
+}
@@ -0,0 +1,170 @@
+// Package push reports the health of a service by pushing it to a monitoring endpoint, in the style of
+// healthchecks.io or the Uptime Kuma push monitors. It is meant for services that cannot be probed, such as workers
+// behind NAT.
+package push
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+// ErrUnexpectedStatus is returned when the monitoring endpoint replies with a non 2xx status code.
+var ErrUnexpectedStatus = errors.New("unexpected status code")
+
+// Healthchecker abstracts the implementation of the svchealthcheck.Healthcheck.
+type Healthchecker interface {
+	Health(ctx context.Context) *svchealthcheck.CheckResponse
+	Ready(ctx context.Context) *svchealthcheck.CheckResponse
+}
+
+// Pusher pushes the CheckResponse of a Healthchecker to a URL.
+type Pusher struct {
+	healthcheck Healthchecker
+	url         string
+	opts        options
+	sleep       func(ctx context.Context, d time.Duration) error
+}
+
+// NewPusher returns a Pusher that posts the CheckResponse, encoded as JSON, to url when the checks pass.
+func NewPusher(healthcheck Healthchecker, url string, opts ...Option) *Pusher {
+	o := defaultOpts()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Pusher{
+		healthcheck: healthcheck,
+		url:         url,
+		opts:        o,
+		sleep:       sleep,
+	}
+}
+
+// Run pushes right away and then on every interval, until the context is done. The errors of the pushes are passed to
+// the handler set by WithErrorHandler.
+func (p *Pusher) Run(ctx context.Context) error {
+	for {
+		if err := p.Push(ctx); err != nil && ctx.Err() == nil && p.opts.errorHandler != nil {
+			p.opts.errorHandler(err)
+		}
+		if err := p.sleep(ctx, p.withJitter(p.opts.interval)); err != nil {
+			return err
+		}
+	}
+}
+
+// Push evaluates the checks and pushes the result once, retrying on failures. It is useful for cron-like jobs, which
+// push once after each run.
+func (p *Pusher) Push(ctx context.Context) error {
+	checkCtx := ctx
+	if p.opts.fullDetail {
+		checkCtx = svchealthcheck.ContextWithAuthenticated(ctx)
+	}
+	response := p.healthcheck.Health
+	if p.opts.kind == svchealthcheck.KindReady {
+		response = p.healthcheck.Ready
+	}
+	r := response(checkCtx)
+
+	url := p.url
+	if r.Outcome.Failed() || r.StatusCode >= http.StatusBadRequest {
+		if p.opts.failURL == "" {
+			return nil
+		}
+		url = p.opts.failURL
+	}
+
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	backoff := p.opts.initialBackoff
+	for attempt := 0; ; attempt++ {
+		err = p.post(ctx, url, body)
+		if err == nil || attempt >= p.opts.retries || !retryable(err) {
+			return err
+		}
+		if sleepErr := p.sleep(ctx, p.withJitter(backoff)); sleepErr != nil {
+			return err
+		}
+		backoff *= 2
+		if backoff > p.opts.maxBackoff {
+			backoff = p.opts.maxBackoff
+		}
+	}
+}
+
+// statusError is the error of a push that received an unexpected status code.
+type statusError struct {
+	statusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s: %d", ErrUnexpectedStatus, e.statusCode)
+}
+
+func (e *statusError) Unwrap() error {
+	return ErrUnexpectedStatus
+}
+
+// retryable returns true for the network errors and the status codes that might succeed when retried.
+func retryable(err error) bool {
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	return statusErr.statusCode >= http.StatusInternalServerError || statusErr.statusCode == http.StatusTooManyRequests
+}
+
+func (p *Pusher) post(ctx context.Context, url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, p.opts.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.opts.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &statusError{statusCode: resp.StatusCode}
+	}
+	return nil
+}
+
+// withJitter randomizes the duration by up to the configured jitter fraction, in both directions.
+func (p *Pusher) withJitter(d time.Duration) time.Duration {
+	if p.opts.jitter <= 0 {
+		return d
+	}
+	// #nosec G404 -- the jitter does not need a secure random source.
+	delta := (rand.Float64()*2 - 1) * p.opts.jitter * float64(d)
+	return d + time.Duration(delta)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,205 @@
+//go:generate go run github.com/golang/mock/mockgen -package push -destination=mocks_mock_test.go . Healthchecker
+
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	svchealthcheck "github.com/jamillosantos/services-healthcheck"
+)
+
+var (
+	passing = &svchealthcheck.CheckResponse{
+		StatusCode: http.StatusOK,
+		Status:     "OK",
+		Outcome:    svchealthcheck.OutcomePass,
+	}
+	failing = &svchealthcheck.CheckResponse{
+		StatusCode: http.StatusServiceUnavailable,
+		Status:     "Service Unavailable",
+		Outcome:    svchealthcheck.OutcomeFail,
+	}
+)
+
+// newServer starts a httptest.Server that replies with the given status codes, in order, repeating the last one. It
+// returns the paths of the requests received.
+func newServer(t *testing.T, statusCodes ...int) (*httptest.Server, <-chan string) {
+	t.Helper()
+	paths := make(chan string, 10)
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response svchealthcheck.CheckResponse
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&response))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		paths <- r.URL.Path
+
+		i := int(atomic.AddInt32(&calls, 1)) - 1
+		if i >= len(statusCodes) {
+			i = len(statusCodes) - 1
+		}
+		w.WriteHeader(statusCodes[i])
+	}))
+	t.Cleanup(srv.Close)
+	return srv, paths
+}
+
+func noSleep(ctx context.Context, _ time.Duration) error {
+	return ctx.Err()
+}
+
+func TestPusher_Push(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should push the health response", func(t *testing.T) {
+		srv, paths := newServer(t, http.StatusOK)
+		mockHC := NewMockHealthchecker(gomock.NewController(t))
+		mockHC.EXPECT().Health(ctx).Return(passing)
+
+		err := NewPusher(mockHC, srv.URL+"/ping").Push(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "/ping", <-paths)
+	})
+
+	t.Run("should push the ready response", func(t *testing.T) {
+		srv, paths := newServer(t, http.StatusOK)
+		mockHC := NewMockHealthchecker(gomock.NewController(t))
+		mockHC.EXPECT().Ready(ctx).Return(passing)
+
+		err := NewPusher(mockHC, srv.URL+"/ping", WithReady()).Push(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "/ping", <-paths)
+	})
+
+	t.Run("should push failures to the fail url", func(t *testing.T) {
+		srv, paths := newServer(t, http.StatusOK)
+		mockHC := NewMockHealthchecker(gomock.NewController(t))
+		mockHC.EXPECT().Health(ctx).Return(failing)
+
+		err := NewPusher(mockHC, srv.URL+"/ping", WithFailURL(srv.URL+"/ping/fail")).Push(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "/ping/fail", <-paths)
+	})
+
+	t.Run("should not push failures without a fail url", func(t *testing.T) {
+		srv, paths := newServer(t, http.StatusOK)
+		mockHC := NewMockHealthchecker(gomock.NewController(t))
+		mockHC.EXPECT().Health(ctx).Return(failing)
+
+		err := NewPusher(mockHC, srv.URL+"/ping").Push(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, paths)
+	})
+
+	t.Run("should push the full detail", func(t *testing.T) {
+		srv, _ := newServer(t, http.StatusOK)
+		mockHC := NewMockHealthchecker(gomock.NewController(t))
+		mockHC.EXPECT().Health(gomock.Any()).DoAndReturn(func(ctx context.Context) *svchealthcheck.CheckResponse {
+			assert.True(t, svchealthcheck.IsAuthenticated(ctx))
+			return passing
+		})
+
+		err := NewPusher(mockHC, srv.URL, WithFullDetail()).Push(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("should retry with backoff", func(t *testing.T) {
+		srv, paths := newServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+		mockHC := NewMockHealthchecker(gomock.NewController(t))
+		mockHC.EXPECT().Health(ctx).Return(passing)
+
+		p := NewPusher(mockHC, srv.URL, WithBackoff(time.Second, 90*time.Second), WithJitter(0))
+		var delays []time.Duration
+		p.sleep = func(ctx context.Context, d time.Duration) error {
+			delays = append(delays, d)
+			return nil
+		}
+
+		err := p.Push(ctx)
+		require.NoError(t, err)
+		assert.Len(t, paths, 3)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, delays)
+	})
+
+	t.Run("should give up after the retries", func(t *testing.T) {
+		srv, paths := newServer(t, http.StatusBadGateway)
+		mockHC := NewMockHealthchecker(gomock.NewController(t))
+		mockHC.EXPECT().Health(ctx).Return(passing)
+
+		p := NewPusher(mockHC, srv.URL, WithRetries(2))
+		p.sleep = noSleep
+
+		err := p.Push(ctx)
+		assert.ErrorIs(t, err, ErrUnexpectedStatus)
+		assert.Len(t, paths, 3)
+	})
+
+	t.Run("should not retry client errors", func(t *testing.T) {
+		srv, paths := newServer(t, http.StatusNotFound)
+		mockHC := NewMockHealthchecker(gomock.NewController(t))
+		mockHC.EXPECT().Health(ctx).Return(passing)
+
+		p := NewPusher(mockHC, srv.URL)
+		p.sleep = noSleep
+
+		err := p.Push(ctx)
+		assert.ErrorIs(t, err, ErrUnexpectedStatus)
+		assert.Len(t, paths, 1)
+	})
+}
+
+func TestPusher_Run(t *testing.T) {
+	srv, paths := newServer(t, http.StatusInternalServerError, http.StatusOK)
+	mockHC := NewMockHealthchecker(gomock.NewController(t))
+	mockHC.EXPECT().Health(gomock.Any()).Return(passing).Times(2)
+
+	errs := make(chan error, 2)
+	p := NewPusher(mockHC, srv.URL, WithRetries(0), WithErrorHandler(func(err error) {
+		errs <- err
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var intervals int
+	p.sleep = func(_ context.Context, d time.Duration) error {
+		intervals++
+		if intervals == 2 {
+			cancel()
+		}
+		return ctx.Err()
+	}
+
+	err := p.Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, paths, 2)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, <-errs, ErrUnexpectedStatus)
+}
+
+func Test_retryable(t *testing.T) {
+	assert.True(t, retryable(errors.New("connection refused")))
+	assert.True(t, retryable(&statusError{statusCode: http.StatusServiceUnavailable}))
+	assert.True(t, retryable(&statusError{statusCode: http.StatusTooManyRequests}))
+	assert.False(t, retryable(&statusError{statusCode: http.StatusBadRequest}))
+}
+
+func TestPusher_withJitter(t *testing.T) {
+	p := NewPusher(nil, "", WithJitter(0.5))
+	for i := 0; i < 100; i++ {
+		d := p.withJitter(time.Second)
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.LessOrEqual(t, d, 1500*time.Millisecond)
+	}
+
+	p = NewPusher(nil, "", WithJitter(0))
+	assert.Equal(t, time.Second, p.withJitter(time.Second))
+}
@@ -0,0 +1,124 @@
+package svchealthcheck
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// RedactedPlaceholder replaces the sensitive information removed by the built-in redactors.
+const RedactedPlaceholder = "[REDACTED]"
+
+var (
+	// urlCredentialsPattern matches the userinfo of URLs up to the last @, as passwords may contain @ and /.
+	urlCredentialsPattern = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://[^:/?#@\s]*):\S*@`)
+	// dsnCredentialsPattern matches the credentials of go-sql-driver/mysql DSNs, such as user:pass@tcp(db:3306)/app
+	// or user:pass@/app.
+	dsnCredentialsPattern = regexp.MustCompile(`([^\s:@/'"=(]+):\S*@([a-z0-9]+\(|/)`)
+	passwordParamPattern  = regexp.MustCompile(`(?i)((?:password|passwd|pwd|secret|token)\s*[=:]\s*)("[^"]*"|'[^']*'|[^\s;&,]+)`)
+)
+
+// Redactor removes sensitive information from the error messages of the checks before they are returned.
+type Redactor interface {
+	Redact(msg string) string
+}
+
+// RedactorFunc is a Redactor defined as a function.
+type RedactorFunc func(msg string) string
+
+// Redact implements the Redactor interface for the RedactorFunc type.
+func (f RedactorFunc) Redact(msg string) string {
+	return f(msg)
+}
+
+// RegexRedactor returns a Redactor that replaces every match of the given patterns with RedactedPlaceholder.
+func RegexRedactor(patterns ...*regexp.Regexp) Redactor {
+	return RedactorFunc(func(msg string) string {
+		for _, p := range patterns {
+			msg = p.ReplaceAllString(msg, RedactedPlaceholder)
+		}
+		return msg
+	})
+}
+
+// SecretRedactor returns a Redactor that replaces every occurrence of the given secrets with RedactedPlaceholder.
+func SecretRedactor(secrets ...string) Redactor {
+	return RedactorFunc(func(msg string) string {
+		for _, secret := range secrets {
+			if secret == "" {
+				continue
+			}
+			msg = strings.ReplaceAll(msg, secret, RedactedPlaceholder)
+		}
+		return msg
+	})
+}
+
+// CredentialsRedactor returns a Redactor that removes passwords from URLs, go-sql-driver/mysql DSNs and the values of
+// password, secret and token key/value pairs.
+func CredentialsRedactor() Redactor {
+	return RedactorFunc(func(msg string) string {
+		msg = urlCredentialsPattern.ReplaceAllString(msg, "${1}:"+RedactedPlaceholder+"@")
+		msg = dsnCredentialsPattern.ReplaceAllString(msg, "${1}:"+RedactedPlaceholder+"@${2}")
+		return passwordParamPattern.ReplaceAllString(msg, "${1}"+RedactedPlaceholder)
+	})
+}
+
+// DetailLevel defines how much of a CheckResponse is exposed.
+type DetailLevel int
+
+const (
+	// DetailFull exposes the checks with their errors.
+	DetailFull DetailLevel = iota
+	// DetailNoErrors exposes the checks without their error messages.
+	DetailNoErrors
+	// DetailStatusOnly exposes only the aggregated status.
+	DetailStatusOnly
+)
+
+// DetailLogger receives the full CheckResponse, before redaction, when the detail of the returned response is reduced.
+type DetailLogger func(ctx context.Context, response *CheckResponse)
+
+type authenticatedKey struct{}
+
+// ContextWithAuthenticated returns a context that marks the request as authenticated. Authenticated requests always
+// receive the full detail of the responses.
+func ContextWithAuthenticated(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authenticatedKey{}, true)
+}
+
+// IsAuthenticated returns true if the context was marked with ContextWithAuthenticated.
+func IsAuthenticated(ctx context.Context) bool {
+	authenticated, _ := ctx.Value(authenticatedKey{}).(bool)
+	return authenticated
+}
+
+// redactEntries returns a copy of the entries, and their components, with the error messages passed through the
+// redactors. When level is DetailNoErrors the error messages are removed.
+func redactEntries(entries map[string]CheckResponseEntry, redactors []Redactor, level DetailLevel) map[string]CheckResponseEntry {
+	if entries == nil {
+		return nil
+	}
+	r := make(map[string]CheckResponseEntry, len(entries))
+	for name, entry := range entries {
+		if level >= DetailNoErrors {
+			entry.Error = ""
+		} else {
+			entry.Error = redact(entry.Error, redactors)
+		}
+		entry.Components = redactEntries(entry.Components, redactors, level)
+		r[name] = entry
+	}
+	return r
+}
+
+// redact passes the message through the redactors. Empty messages are returned as they are.
+func redact(msg string, redactors []Redactor) string {
+	if msg == "" {
+		return msg
+	}
+	for _, redactor := range redactors {
+		msg = redactor.Redact(msg)
+	}
+	return msg
+}
@@ -0,0 +1,163 @@
+package svchealthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UnixScheme is the prefix of the bind addresses of Unix domain sockets, such as unix:///var/run/health.sock.
+const UnixScheme = "unix://"
+
+// server is the state of the server mode, started by Healthcheck.Listen.
+type server struct {
+	mu         sync.Mutex
+	app        *fiber.App
+	socketPath string
+}
+
+// Name returns the name of the server.
+func (s *Healthcheck) Name() string {
+	return "healthcheck"
+}
+
+// Listen serves the health, ready and history endpoints on the address set by WithBindAddress, blocking until Close
+// is called. The initializer set by WithInitializer can add other routes and middlewares.
+//
+// Addresses prefixed by UnixScheme are served on a Unix domain socket, created with the permissions set by
+// WithSocketMode. A stale socket left at the path, refusing connections, is replaced. A socket accepting connections
+// or any other file makes Listen fail.
+func (s *Healthcheck) Listen(_ context.Context) error {
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+	})
+	app.Get(HealthPath, s.fiberHandler(s.Health))
+	app.Get(ReadyPath, s.fiberHandler(s.Ready))
+	app.Get(HistoryPath, func(ctx *fiber.Ctx) error {
+		reqCtx, cancel := requestContext(ctx)
+		defer cancel()
+		return ctx.JSON(s.Histories(reqCtx))
+	})
+	if s.initializer != nil {
+		if err := s.initializer(app); err != nil {
+			return err
+		}
+	}
+
+	if !strings.HasPrefix(s.bindAddress, UnixScheme) {
+		s.server.mu.Lock()
+		s.server.app = app
+		s.server.mu.Unlock()
+		return app.Listen(s.bindAddress)
+	}
+
+	path := strings.TrimPrefix(s.bindAddress, UnixScheme)
+	lis, err := listenUnix(path, s.socketMode)
+	if err != nil {
+		return err
+	}
+	s.server.mu.Lock()
+	s.server.app = app
+	s.server.socketPath = path
+	s.server.mu.Unlock()
+	return app.Listener(lis)
+}
+
+// Close shuts the server started by Listen down, removing its Unix domain socket.
+func (s *Healthcheck) Close(_ context.Context) error {
+	s.server.mu.Lock()
+	app, path := s.server.app, s.server.socketPath
+	s.server.app, s.server.socketPath = nil, ""
+	s.server.mu.Unlock()
+
+	if app == nil {
+		return nil
+	}
+	err := app.Shutdown()
+	if path != "" {
+		if rmErr := os.Remove(path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+			err = errors.Join(err, rmErr)
+		}
+	}
+	return err
+}
+
+func (s *Healthcheck) fiberHandler(getResponse func(ctx context.Context) *CheckResponse) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		reqCtx, cancel := requestContext(ctx)
+		defer cancel()
+		r := getResponse(reqCtx)
+		return ctx.Status(r.StatusCode).JSON(r)
+	}
+}
+
+// requestContext returns the context of the request, done when the request context of fasthttp is, as the one used by
+// hcfiber. fasthttp only closes it when the server shuts down, not when the client disconnects, so Close cancels the
+// running checks. fasthttp resets its done channel on shutdown, so the channel is read here, while the request is still
+// being served, instead of by the goroutines of the contexts derived from it.
+func requestContext(ctx *fiber.Ctx) (context.Context, context.CancelFunc) {
+	done := ctx.Context().Done()
+	reqCtx, cancel := context.WithCancel(ctx.UserContext())
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-reqCtx.Done():
+		}
+	}()
+	return reqCtx, cancel
+}
+
+// listenUnix listens on the Unix domain socket at path, with its permissions set to mode. The socket is created in a
+// private directory next to path and moved to path once its permissions are set, so it is never reachable with the
+// permissions given by the umask.
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("cannot listen on %s: file exists and is not a socket", path)
+		}
+		conn, err := net.Dial("unix", path)
+		if err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("cannot listen on %s: socket in use", path)
+		}
+		if !errors.Is(err, syscall.ECONNREFUSED) {
+			return nil, fmt.Errorf("cannot listen on %s: %w", path, err)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".healthcheck-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	tmpPath := filepath.Join(dir, "sock")
+	lis, err := net.Listen("unix", tmpPath)
+	if err != nil {
+		return nil, err
+	}
+	// The socket is removed by Close from its final path.
+	lis.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(tmpPath, mode); err != nil {
+		_ = lis.Close()
+		return nil, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = lis.Close()
+		return nil, err
+	}
+	return lis, nil
+}
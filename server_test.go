@@ -0,0 +1,208 @@
+package svchealthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts the server mode of the Healthcheck, waiting until it accepts connections on the given network
+// address.
+func startServer(t *testing.T, hc *Healthcheck, network, address string) <-chan error {
+	t.Helper()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- hc.Listen(context.Background())
+	}()
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial(network, address)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return true
+	}, time.Second, 10*time.Millisecond)
+	return errs
+}
+
+func getJSON(t *testing.T, client *http.Client, url string, v interface{}) int {
+	t.Helper()
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	return resp.StatusCode
+}
+
+func TestHealthcheck_Listen(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	hc := NewHealthcheck(
+		WithBindAddress(addr),
+		WithReadyCheck("database", CheckerFunc(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})),
+		WithInitializer(func(app *fiber.App) error {
+			app.Get("/version", func(ctx *fiber.Ctx) error {
+				return ctx.JSON("v1")
+			})
+			return nil
+		}),
+	)
+	errs := startServer(t, hc, "tcp", addr)
+
+	var response CheckResponse
+	assert.Equal(t, http.StatusOK, getJSON(t, http.DefaultClient, "http://"+addr+HealthPath, &response))
+	assert.Equal(t, http.StatusServiceUnavailable, getJSON(t, http.DefaultClient, "http://"+addr+ReadyPath, &response))
+	assert.Equal(t, "connection refused", response.Checks["database"].Error)
+
+	var histories map[string]CheckHistory
+	assert.Equal(t, http.StatusOK, getJSON(t, http.DefaultClient, "http://"+addr+HistoryPath, &histories))
+	assert.Contains(t, histories, "database")
+
+	var version string
+	getJSON(t, http.DefaultClient, "http://"+addr+"/version", &version)
+	assert.Equal(t, "v1", version)
+
+	require.NoError(t, hc.Close(context.Background()))
+	assert.NoError(t, <-errs)
+}
+
+func TestHealthcheck_Listen_unixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "health.sock")
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+
+	t.Run("should serve on the socket with the given permissions", func(t *testing.T) {
+		hc := NewHealthcheck(WithBindAddress(UnixScheme+path), WithSocketMode(0o600))
+		errs := startServer(t, hc, "unix", path)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		require.Len(t, entries, 1, "the directory used to create the socket should be removed")
+		assert.Equal(t, "health.sock", entries[0].Name())
+
+		var response CheckResponse
+		assert.Equal(t, http.StatusOK, getJSON(t, client, "http://unix"+HealthPath, &response))
+
+		require.NoError(t, hc.Close(context.Background()))
+		assert.NoError(t, <-errs)
+		_, err = os.Stat(path)
+		assert.ErrorIs(t, err, fs.ErrNotExist, "the socket should be removed on close")
+	})
+
+	t.Run("should replace a stale socket", func(t *testing.T) {
+		lis, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		lis.(*net.UnixListener).SetUnlinkOnClose(false)
+		require.NoError(t, lis.Close())
+
+		hc := NewHealthcheck(WithBindAddress(UnixScheme + path))
+		errs := startServer(t, hc, "unix", path)
+
+		require.NoError(t, hc.Close(context.Background()))
+		assert.NoError(t, <-errs)
+	})
+
+	t.Run("should not replace a socket in use", func(t *testing.T) {
+		lis, err := net.Listen("unix", path)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = lis.Close()
+		})
+
+		hc := NewHealthcheck(WithBindAddress(UnixScheme + path))
+		err = hc.Listen(context.Background())
+		assert.ErrorContains(t, err, "socket in use")
+		_, err = os.Stat(path)
+		assert.NoError(t, err, "the socket in use should be kept")
+	})
+
+	t.Run("should not replace other files", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("data"), 0o600))
+		t.Cleanup(func() {
+			_ = os.Remove(path)
+		})
+
+		hc := NewHealthcheck(WithBindAddress(UnixScheme + path))
+		err := hc.Listen(context.Background())
+		assert.ErrorContains(t, err, fmt.Sprintf("cannot listen on %s", path))
+	})
+}
+
+func TestHealthcheck_Close(t *testing.T) {
+	t.Run("should do nothing when not listening", func(t *testing.T) {
+		assert.NoError(t, NewHealthcheck().Close(context.Background()))
+	})
+
+	t.Run("should cancel the running checks", func(t *testing.T) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := lis.Addr().String()
+		require.NoError(t, lis.Close())
+
+		started := make(chan struct{})
+		hc := NewHealthcheck(
+			WithBindAddress(addr),
+			WithTimeout(0),
+			WithCheck("blocked", CheckerFunc(func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			})),
+		)
+		errs := startServer(t, hc, "tcp", addr)
+
+		statuses := make(chan int, 1)
+		go func() {
+			resp, err := http.Get("http://" + addr + HealthPath)
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			_ = resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+
+		<-started
+		require.NoError(t, hc.Close(context.Background()))
+		assert.NoError(t, <-errs)
+		select {
+		case <-statuses:
+		case <-time.After(time.Second * 5):
+			require.FailNow(t, "the check was not cancelled")
+		}
+	})
+}
+
+func TestHealthcheck_Listen_initializerError(t *testing.T) {
+	wantErr := errors.New("initializer failed")
+	hc := NewHealthcheck(WithInitializer(func(app *fiber.App) error {
+		return wantErr
+	}))
+	assert.ErrorIs(t, hc.Listen(context.Background()), wantErr)
+}
@@ -0,0 +1,52 @@
+package svchealthcheck
+
+import (
+	"net/http"
+)
+
+// Aggregate is the aggregated result of the checks of a response.
+type Aggregate struct {
+	// Outcome is the worst outcome of the checks.
+	Outcome Outcome
+	// Panicked is true if any of the checks panicked.
+	Panicked bool
+	// Counts is the number of checks per outcome.
+	Counts map[Outcome]int
+}
+
+// StatusPolicy maps the aggregated result of the checks into the HTTP status code and status text of the response.
+// An empty status text defaults to http.StatusText of the code.
+type StatusPolicy interface {
+	Status(aggregate Aggregate) (code int, text string)
+}
+
+// StatusPolicyFunc is a StatusPolicy defined as a function.
+type StatusPolicyFunc func(aggregate Aggregate) (int, string)
+
+// Status implements the StatusPolicy interface for the StatusPolicyFunc type.
+func (f StatusPolicyFunc) Status(aggregate Aggregate) (int, string) {
+	return f(aggregate)
+}
+
+// OutcomeStatusPolicy is the default StatusPolicy. It maps the worst outcome into a status code, unless a check
+// panicked.
+type OutcomeStatusPolicy struct {
+	// Codes maps each outcome into a status code. Missing outcomes result in http.StatusServiceUnavailable.
+	Codes map[Outcome]int
+	// PanicCode is used when a check panicked. It defaults to http.StatusInternalServerError.
+	PanicCode int
+}
+
+// Status implements the StatusPolicy interface.
+func (p *OutcomeStatusPolicy) Status(aggregate Aggregate) (int, string) {
+	if aggregate.Panicked {
+		if p.PanicCode != 0 {
+			return p.PanicCode, ""
+		}
+		return http.StatusInternalServerError, ""
+	}
+	if code, ok := p.Codes[aggregate.Outcome]; ok {
+		return code, ""
+	}
+	return http.StatusServiceUnavailable, ""
+}
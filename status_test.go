@@ -0,0 +1,77 @@
+package svchealthcheck
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutcomeStatusPolicy_Status(t *testing.T) {
+	policy := &OutcomeStatusPolicy{
+		Codes: map[Outcome]int{
+			OutcomePass: http.StatusOK,
+			OutcomeWarn: http.StatusTooManyRequests,
+		},
+	}
+
+	t.Run("should map the outcome into the configured code", func(t *testing.T) {
+		code, text := policy.Status(Aggregate{Outcome: OutcomeWarn})
+		assert.Equal(t, http.StatusTooManyRequests, code)
+		assert.Empty(t, text)
+	})
+
+	t.Run("should default to service unavailable for unmapped outcomes", func(t *testing.T) {
+		code, _ := policy.Status(Aggregate{Outcome: OutcomeFail})
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+	})
+
+	t.Run("should default to internal server error on panics", func(t *testing.T) {
+		code, _ := policy.Status(Aggregate{Outcome: OutcomeFail, Panicked: true})
+		assert.Equal(t, http.StatusInternalServerError, code)
+	})
+
+	t.Run("should use the configured panic code", func(t *testing.T) {
+		policy := &OutcomeStatusPolicy{PanicCode: http.StatusServiceUnavailable}
+		code, _ := policy.Status(Aggregate{Outcome: OutcomeFail, Panicked: true})
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+	})
+}
+
+func TestWithStatusPolicy_generateResponse(t *testing.T) {
+	checks := map[string]Checker{
+		"check1": CheckerFunc(func(ctx context.Context) error {
+			return nil
+		}),
+		"check2": CheckerFunc(func(ctx context.Context) error {
+			return errors.New("failed")
+		}),
+	}
+
+	t.Run("should use the custom policy", func(t *testing.T) {
+		var got Aggregate
+		hc := NewHealthcheck(WithStatusPolicy(StatusPolicyFunc(func(aggregate Aggregate) (int, string) {
+			got = aggregate
+			return http.StatusOK, "degraded"
+		})))
+
+		response := hc.generateResponse(context.Background(), KindHealth, checks)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "degraded", response.Status)
+		assert.Equal(t, OutcomeFail, got.Outcome)
+		assert.Equal(t, map[Outcome]int{OutcomePass: 1, OutcomeFail: 1}, got.Counts)
+	})
+
+	t.Run("should use the configured panic status code", func(t *testing.T) {
+		hc := NewHealthcheck(WithPanicStatusCode(http.StatusServiceUnavailable))
+		response := hc.generateResponse(context.Background(), KindHealth, map[string]Checker{
+			"check": CheckerFunc(func(ctx context.Context) error {
+				panic("boom")
+			}),
+		})
+		assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		assert.Equal(t, "Service Unavailable", response.Status)
+	})
+}
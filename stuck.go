@@ -0,0 +1,77 @@
+package svchealthcheck
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrCheckStillRunning is returned, without running the check, when a previous run of the same check timed out and
+	// is still running.
+	ErrCheckStillRunning = errors.New("previous run of the check is still running")
+)
+
+// stuckTracker keeps track of the check goroutines that were abandoned after a timeout and are still running.
+type stuckTracker struct {
+	mu      sync.Mutex
+	running map[checkKey]int
+	total   int
+}
+
+// checkKey identifies a check by the set it is evaluated in and its name, as Health and Ready can have different
+// checks with the same name.
+type checkKey struct {
+	kind CheckKind
+	name string
+}
+
+// checkRun is a single run of a check.
+type checkRun struct {
+	key       checkKey
+	done      bool
+	abandoned bool
+}
+
+// isStuck returns true if there are abandoned runs of the check with the given key still running.
+func (t *stuckTracker) isStuck(key checkKey) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.running[key] > 0
+}
+
+// abandon marks the run as abandoned, unless it is already done.
+func (t *stuckTracker) abandon(run *checkRun) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if run.done {
+		return
+	}
+	run.abandoned = true
+	if t.running == nil {
+		t.running = make(map[checkKey]int)
+	}
+	t.running[run.key]++
+	t.total++
+}
+
+// finish marks the run as done, releasing it if it was abandoned.
+func (t *stuckTracker) finish(run *checkRun) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	run.done = true
+	if !run.abandoned {
+		return
+	}
+	t.running[run.key]--
+	if t.running[run.key] == 0 {
+		delete(t.running, run.key)
+	}
+	t.total--
+}
+
+// count returns the number of abandoned runs still running.
+func (t *stuckTracker) count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total
+}
@@ -0,0 +1,152 @@
+package svchealthcheck
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_stuckTracker(t *testing.T) {
+	key := checkKey{kind: KindHealth, name: "check"}
+
+	t.Run("should not track runs that finish before being abandoned", func(t *testing.T) {
+		var tracker stuckTracker
+		run := &checkRun{key: key}
+		tracker.finish(run)
+		tracker.abandon(run)
+		assert.False(t, tracker.isStuck(key))
+		assert.Equal(t, 0, tracker.count())
+	})
+
+	t.Run("should track abandoned runs until they finish", func(t *testing.T) {
+		var tracker stuckTracker
+		run1 := &checkRun{key: key}
+		run2 := &checkRun{key: key}
+		tracker.abandon(run1)
+		tracker.abandon(run2)
+		assert.True(t, tracker.isStuck(key))
+		assert.False(t, tracker.isStuck(checkKey{kind: KindHealth, name: "other"}))
+		assert.False(t, tracker.isStuck(checkKey{kind: KindReady, name: "check"}))
+		assert.Equal(t, 2, tracker.count())
+
+		tracker.finish(run1)
+		assert.True(t, tracker.isStuck(key))
+		assert.Equal(t, 1, tracker.count())
+
+		tracker.finish(run2)
+		assert.False(t, tracker.isStuck(key))
+		assert.Equal(t, 0, tracker.count())
+	})
+}
+
+func TestHealthcheck_abandonedChecks_kinds(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	hc := NewHealthcheck(
+		WithTimeout(time.Millisecond*50),
+		WithCheck("database", CheckerFunc(func(ctx context.Context) error {
+			<-unblock // ignores the context
+			return nil
+		})),
+		WithReadyCheck("database", CheckerFunc(func(ctx context.Context) error {
+			return nil
+		})),
+	)
+
+	response := hc.Health(context.Background())
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+
+	response = hc.Ready(context.Background())
+	assert.Equal(t, http.StatusOK, response.StatusCode, "a stuck health check should not block the ready check of the same name")
+}
+
+func TestHealthcheck_abandonedChecks(t *testing.T) {
+	unblock := make(chan struct{})
+	var calls atomic.Int32
+	hc := NewHealthcheck(
+		WithTimeout(time.Millisecond*50),
+		WithCheck("stuck", CheckerFunc(func(ctx context.Context) error {
+			calls.Add(1)
+			<-unblock // ignores the context
+			return nil
+		})),
+	)
+
+	response := hc.Health(context.Background())
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	assert.Equal(t, context.DeadlineExceeded.Error(), response.Checks["stuck"].Error)
+	assert.Equal(t, 1, response.AbandonedChecks)
+	assert.Equal(t, 1, hc.AbandonedChecks())
+
+	response = hc.Health(context.Background())
+	assert.Equal(t, ErrCheckStillRunning.Error(), response.Checks["stuck"].Error)
+	assert.Equal(t, int32(1), calls.Load(), "the stuck check should not run again")
+
+	close(unblock)
+	assert.Eventually(t, func() bool {
+		return hc.AbandonedChecks() == 0
+	}, time.Second, time.Millisecond*5)
+
+	response = hc.Health(context.Background())
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, 0, response.AbandonedChecks)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestHealthcheck_maxParallelism(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	check := CheckerFunc(func(ctx context.Context) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond * 20)
+		return nil
+	})
+
+	hc := NewHealthcheck(WithMaxParallelism(2))
+	response := hc.generateResponse(context.Background(), KindHealth, map[string]Checker{
+		"check1": check,
+		"check2": check,
+		"check3": check,
+		"check4": check,
+		"check5": check,
+	})
+
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Len(t, response.Checks, 5)
+	assert.Equal(t, int32(2), maxRunning.Load())
+}
+
+func TestHealthcheck_generateResponse_timeoutPerCheck(t *testing.T) {
+	check := CheckerFunc(func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond * 100):
+			return nil
+		}
+	})
+
+	hc := NewHealthcheck(WithMaxParallelism(1), WithTimeout(time.Millisecond*300))
+	response := hc.generateResponse(context.Background(), KindHealth, map[string]Checker{
+		"check1": check,
+		"check2": check,
+		"check3": check,
+		"check4": check,
+	})
+
+	assert.Equal(t, http.StatusOK, response.StatusCode, "the checks waiting for a worker should not time out")
+	for name, entry := range response.Checks {
+		assert.Equal(t, OutcomePass, entry.Outcome, name)
+	}
+	assert.Zero(t, hc.AbandonedChecks())
+}